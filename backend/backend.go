@@ -0,0 +1,99 @@
+// Package backend provides randomness sources behind services.RandRetiever,
+// so generator commands work the same with random.org and local sources.
+package backend
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	mrand "math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+	"github.com/bohdanch-w/rand-api/pool"
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const ErrUnknownBackend = entities.Error("unknown backend")
+
+type Name string
+
+const (
+	RandomOrg Name = randapi.SourceName
+	Crypto    Name = "crypto"
+	Seeded    Name = "seeded"
+	Pool      Name = "pool"
+)
+
+// ParseName validates backend name given on command line.
+func ParseName(s string) (Name, error) {
+	switch n := Name(strings.ToLower(s)); n {
+	case RandomOrg, Crypto, Seeded, Pool:
+		return n, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownBackend, s)
+	}
+}
+
+// NewCrypto returns backend reading operating system CSPRNG.
+func NewCrypto() *Local {
+	return &Local{name: Crypto, src: &unlimitedSource{r: rand.Reader}}
+}
+
+// NewSeeded returns backend producing the same values for the same seed.
+// Not suitable where unpredictability matters.
+func NewSeeded(seed string) *Local {
+	return &Local{name: Seeded, src: &unlimitedSource{r: mrand.NewChaCha8(sha256.Sum256([]byte(seed)))}}
+}
+
+// NewPool returns backend consuming bytes prefetched into p.
+func NewPool(p *pool.Pool) *Local {
+	return &Local{name: Pool, src: poolSource{pool: p}}
+}
+
+// unlimitedSource serializes reads, so seeded values don't depend on interleaving of concurrent calls.
+type unlimitedSource struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (s *unlimitedSource) consume(fn func(r io.Reader) error) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := derive.NewCountingReader(s.r)
+
+	if err := fn(r); err != nil {
+		return 0, 0, err
+	}
+
+	return r.Count(), -1, nil
+}
+
+func (*unlimitedSource) usage() (entities.UsageStatus, error) {
+	return entities.UsageStatus{}, ErrUsageUnavailable
+}
+
+type poolSource struct {
+	pool *pool.Pool
+}
+
+func (s poolSource) consume(fn func(r io.Reader) error) (int, int, error) {
+	return s.pool.Consume(fn) // nolint: wrapcheck
+}
+
+func (s poolSource) usage() (entities.UsageStatus, error) {
+	status, err := s.pool.Status()
+	if err != nil {
+		return entities.UsageStatus{}, fmt.Errorf("get pool status: %w", err)
+	}
+
+	return entities.UsageStatus{
+		Status:       "running",
+		CreationTime: entities.RandTime(status.Oldest),
+		BitsLeft:     uint64(status.Bytes) * bitsInByte,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+// CryptoFallback marks values generated with crypto backend because random.org was unreachable.
+const CryptoFallback = "crypto-fallback"
+
+var _ services.RandRetiever = (*Fallback)(nil)
+
+// NewFallback returns retriever generating values locally with crypto backend
+// when primary fails with randapi.ErrUnreachable. Warn, if set, is called
+// with the original error every time fallback is used.
+func NewFallback(primary services.RandRetiever, warn func(err error)) *Fallback {
+	return &Fallback{
+		primary: primary,
+		local:   NewCrypto(),
+		warn:    warn,
+	}
+}
+
+type Fallback struct {
+	primary services.RandRetiever
+	local   *Local
+	warn    func(err error)
+	used    atomic.Bool
+}
+
+// Used reports whether any values were generated by fallback.
+func (svc *Fallback) Used() bool {
+	return svc.used.Load()
+}
+
+func (svc *Fallback) NewRequest(
+	method string,
+	params services.RandParameters,
+) (entities.RandomRequest, error) {
+	return svc.primary.NewRequest(method, params) // nolint: wrapcheck
+}
+
+func (svc *Fallback) ExecuteRequest(
+	ctx context.Context,
+	randReq *entities.RandomRequest,
+) (entities.RandResponseResult, error) {
+	result, err := svc.primary.ExecuteRequest(ctx, randReq)
+	if err == nil || !errors.Is(err, randapi.ErrUnreachable) {
+		return result, err // nolint: wrapcheck
+	}
+
+	result, ferr := svc.local.ExecuteRequest(ctx, randReq)
+	if ferr != nil {
+		return result, fmt.Errorf("%w (fallback failed: %s)", err, ferr)
+	}
+
+	svc.used.Store(true)
+
+	if svc.warn != nil {
+		svc.warn(err)
+	}
+
+	result.Source = CryptoFallback
+
+	return result, nil
+}
+
+func (svc *Fallback) GetUsage(ctx context.Context, apiKey string) (entities.UsageStatus, error) {
+	return svc.primary.GetUsage(ctx, apiKey) // nolint: wrapcheck
+}
@@ -0,0 +1,56 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+func TestFallback(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close() // nothing listens on the address anymore
+
+	var warned error
+
+	svc := backend.NewFallback(
+		randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false),
+		func(err error) { warned = err },
+	)
+
+	req, err := svc.NewRequest("generateIntegers", map[string]interface{}{"n": 3, "min": 1, "max": 6, "replacement": true})
+	require.NoError(t, err)
+
+	result, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+	require.Equal(t, backend.CryptoFallback, result.Source)
+	require.True(t, svc.Used())
+	require.ErrorIs(t, warned, randapi.ErrUnreachable)
+
+	var ints []int
+
+	require.NoError(t, json.Unmarshal(result.Random.Data, &ints))
+	require.Len(t, ints, 3)
+}
+
+func TestFallback_NotUsedForRejectedRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	svc := backend.NewFallback(randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false), nil)
+
+	req, err := svc.NewRequest("generateIntegers", map[string]interface{}{"n": 1, "min": 1, "max": 6})
+	require.NoError(t, err)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, randapi.ErrUnexpectedStatusCode)
+	require.False(t, svc.Used())
+}
@@ -0,0 +1,247 @@
+package backend
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+const (
+	ErrUnsupportedMethod = entities.Error("method is not supported by local backend")
+	ErrUnsupportedParams = entities.Error("parameters are not supported by local backend")
+	ErrUsageUnavailable  = entities.Error("backend has no usage quota")
+
+	bitsInByte = 8
+)
+
+var _ services.RandRetiever = (*Local)(nil)
+
+// source supplies random bytes to local backend.
+type source interface {
+	// consume passes bytes to fn and returns number of bytes fn has read
+	// and bytes left, negative if source is unlimited.
+	consume(fn func(r io.Reader) error) (int, int, error)
+	usage() (entities.UsageStatus, error)
+}
+
+// Local derives values from source bytes instead of calling random.org.
+type Local struct {
+	name Name
+	src  source
+}
+
+func (svc *Local) Name() Name {
+	return svc.name
+}
+
+func (svc *Local) NewRequest(
+	method string,
+	params services.RandParameters,
+) (entities.RandomRequest, error) {
+	const errParameterInvalid = entities.Error("invalid parameters")
+
+	if params == nil {
+		return entities.RandomRequest{}, errParameterInvalid
+	}
+
+	bb, err := json.Marshal(params)
+	if err != nil {
+		return entities.RandomRequest{}, fmt.Errorf("marhsal params: %w", err)
+	}
+
+	return entities.RandomRequest{
+		ID:     uuid.New(),
+		Method: method,
+		Params: bb,
+	}, nil
+}
+
+func (svc *Local) ExecuteRequest(
+	_ context.Context,
+	randReq *entities.RandomRequest,
+) (entities.RandResponseResult, error) {
+	var (
+		result entities.RandResponseResult
+		params struct {
+			PregenRand json.RawMessage `json:"pregeneratedRandomization"`
+		}
+		values interface{}
+	)
+
+	if err := json.Unmarshal(randReq.Params, &params); err != nil {
+		return result, fmt.Errorf("decode params: %w", err)
+	}
+
+	if len(params.PregenRand) > 0 && string(params.PregenRand) != "null" {
+		return result, fmt.Errorf("%w: pregenerated randomization", ErrUnsupportedParams)
+	}
+
+	fn, err := deriveFunc(randReq.Method, randReq.Params, &values)
+	if err != nil {
+		return result, err
+	}
+
+	used, left, err := svc.src.consume(fn)
+	if err != nil {
+		return result, fmt.Errorf("%s backend: %w", svc.name, err)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return result, fmt.Errorf("encode values: %w", err)
+	}
+
+	result.Random = entities.RandomData{
+		Data:      data,
+		Timestamp: entities.RandTime(time.Now().UTC()),
+	}
+	result.BitsUsed = uint64(used) * bitsInByte
+	result.Source = string(svc.name)
+
+	if left > 0 {
+		result.BitsLeft = uint64(left) * bitsInByte
+	}
+
+	return result, nil
+}
+
+// GetUsage reports content of limited sources. Requests are not limited locally.
+func (svc *Local) GetUsage(context.Context, string) (entities.UsageStatus, error) {
+	return svc.src.usage()
+}
+
+type requestParams struct {
+	Number            int     `json:"n"`
+	Min               int64   `json:"min"`
+	Max               int64   `json:"max"`
+	Replacement       bool    `json:"replacement"`
+	Base              int     `json:"base"`
+	DecimalPlaces     int     `json:"decimalPlaces"`
+	Mean              float64 `json:"mean"`
+	StandardDeviation float64 `json:"standardDeviation"`
+	SignificantDigits int     `json:"significantDigits"`
+	Length            int     `json:"length"`
+	Characters        string  `json:"characters"`
+	Size              int     `json:"size"`
+	Format            string  `json:"format"`
+}
+
+func deriveFunc(method string, rawParams json.RawMessage, values *interface{}) (func(io.Reader) error, error) {
+	if method == "generateIntegerSequences" {
+		return sequencesFunc(rawParams, values)
+	}
+
+	var p requestParams
+
+	if err := json.Unmarshal(rawParams, &p); err != nil {
+		return nil, fmt.Errorf("decode params: %w", err)
+	}
+
+	switch method {
+	case "generateIntegers":
+		format, err := integerFormat(p.Base)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(r io.Reader) error {
+			ints, err := derive.Integers(r, p.Number, p.Min, p.Max, p.Replacement)
+			*values = format(ints)
+
+			return err
+		}, nil
+	case "generateDecimalFractions":
+		return func(r io.Reader) (err error) {
+			*values, err = derive.Decimals(r, p.Number, p.DecimalPlaces, p.Replacement)
+
+			return err
+		}, nil
+	case "generateGaussians":
+		return func(r io.Reader) (err error) {
+			*values, err = derive.Gaussians(r, p.Number, p.Mean, p.StandardDeviation, p.SignificantDigits)
+
+			return err
+		}, nil
+	case "generateStrings":
+		return func(r io.Reader) (err error) {
+			*values, err = derive.Strings(r, p.Number, p.Length, p.Characters, p.Replacement)
+
+			return err
+		}, nil
+	case "generateUUIDs":
+		return func(r io.Reader) (err error) {
+			*values, err = derive.UUIDs(r, p.Number)
+
+			return err
+		}, nil
+	case "generateBlobs":
+		encode, err := blobFormat(p.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(r io.Reader) error {
+			blobs, err := derive.Blobs(r, p.Number, p.Size/bitsInByte)
+			*values = encode(blobs)
+
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+	}
+}
+
+// integerFormat returns integers as numbers in base 10 and as strings
+// in other bases, like random.org does.
+func integerFormat(base int) (func([]int64) interface{}, error) {
+	switch base {
+	case 0, 10: // nolint: gomnd
+		return func(ints []int64) interface{} { return ints }, nil
+	case 2, 8, 16: // nolint: gomnd
+		return func(ints []int64) interface{} {
+			res := make([]string, 0, len(ints))
+
+			for _, v := range ints {
+				res = append(res, strconv.FormatInt(v, base))
+			}
+
+			return res
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: base %d", ErrUnsupportedParams, base)
+	}
+}
+
+func blobFormat(format string) (func([][]byte) interface{}, error) {
+	var encode func([]byte) string
+
+	switch format {
+	case "", "base64":
+		encode = base64.StdEncoding.EncodeToString
+	case "hex":
+		encode = hex.EncodeToString
+	default:
+		return nil, fmt.Errorf("%w: format %q", ErrUnsupportedParams, format)
+	}
+
+	return func(blobs [][]byte) interface{} {
+		res := make([]string, 0, len(blobs))
+
+		for _, b := range blobs {
+			res = append(res, encode(b))
+		}
+
+		return res
+	}, nil
+}
@@ -0,0 +1,195 @@
+package backend_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/pool"
+)
+
+func newPool(t *testing.T, data []byte) *pool.Pool {
+	t.Helper()
+
+	p := pool.New(filepath.Join(t.TempDir(), "pool.json"))
+
+	require.NoError(t, p.Add(pool.Chunk{
+		Source:    pool.SourceRandomOrg,
+		RequestID: uuid.New(),
+		FetchedAt: time.Date(2022, 8, 25, 12, 0, 0, 0, time.UTC),
+		Size:      len(data),
+		Data:      data,
+	}))
+
+	return p
+}
+
+func execute(t *testing.T, svc *backend.Local, method string, params interface{}, values interface{}) uint64 {
+	t.Helper()
+
+	req, err := svc.NewRequest(method, params)
+	require.NoError(t, err)
+
+	result, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+	require.Equal(t, string(svc.Name()), result.Source)
+	require.NoError(t, json.Unmarshal(result.Random.Data, values))
+
+	return result.BitsUsed
+}
+
+func TestLocal(t *testing.T) {
+	data := make([]byte, 4096)
+
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for _, svc := range []*backend.Local{
+		backend.NewPool(newPool(t, data)),
+		backend.NewCrypto(),
+		backend.NewSeeded("fixtures"),
+	} {
+		var ints []int
+		execute(t, svc, "generateIntegers", map[string]interface{}{
+			"n": 50, "min": 1, "max": 50, "replacement": false, "base": 10,
+		}, &ints)
+		require.ElementsMatch(t, func() []int {
+			all := make([]int, 0, 50)
+			for i := 1; i <= 50; i++ {
+				all = append(all, i)
+			}
+
+			return all
+		}(), ints)
+
+		var decimals []float64
+		execute(t, svc, "generateDecimalFractions", map[string]interface{}{
+			"n": 10, "decimalPlaces": 2, "replacement": true,
+		}, &decimals)
+		require.Len(t, decimals, 10)
+
+		for _, v := range decimals {
+			require.GreaterOrEqual(t, v, 0.0)
+			require.Less(t, v, 1.0)
+		}
+
+		var strs []string
+		execute(t, svc, "generateStrings", map[string]interface{}{
+			"n": 5, "length": 8, "characters": "ab", "replacement": true,
+		}, &strs)
+		require.Len(t, strs, 5)
+
+		for _, s := range strs {
+			require.Regexp(t, "^[ab]{8}$", s)
+		}
+
+		var gaussians []float64
+		execute(t, svc, "generateGaussians", map[string]interface{}{
+			"n": 4, "mean": 0, "standardDeviation": 1, "significantDigits": 5,
+		}, &gaussians)
+		require.Len(t, gaussians, 4)
+
+		var uuids []uuid.UUID
+		bitsUsed := execute(t, svc, "generateUUIDs", map[string]interface{}{"n": 2}, &uuids)
+		require.Len(t, uuids, 2)
+		require.Equal(t, uint64(2*16*8), bitsUsed)
+		require.Equal(t, uuid.Version(4), uuids[0].Version())
+		require.Equal(t, uuid.RFC4122, uuids[0].Variant())
+	}
+}
+
+func TestSeeded(t *testing.T) {
+	generate := func(seed string) []int {
+		var ints []int
+
+		execute(t, backend.NewSeeded(seed), "generateIntegers", map[string]interface{}{
+			"n": 20, "min": 1, "max": 1000000, "replacement": true,
+		}, &ints)
+
+		return ints
+	}
+
+	require.Equal(t, generate("a"), generate("a"))
+	require.NotEqual(t, generate("a"), generate("b"))
+}
+
+func TestSeeded_IntegerSequences(t *testing.T) {
+	svc := backend.NewSeeded("sequences")
+
+	var seqs [][]int
+	execute(t, svc, "generateIntegerSequences", map[string]interface{}{
+		"n": 2, "length": []int{5, 2}, "min": 1, "max": []int{50, 12}, "replacement": false,
+	}, &seqs)
+	require.Len(t, seqs, 2)
+	require.Len(t, seqs[0], 5)
+	require.Len(t, seqs[1], 2)
+
+	for i, max := range []int{50, 12} {
+		seen := make(map[int]bool)
+
+		for _, v := range seqs[i] {
+			require.False(t, seen[v])
+			require.True(t, v >= 1 && v <= max)
+			seen[v] = true
+		}
+	}
+
+	var hex [][]string
+	execute(t, svc, "generateIntegerSequences", map[string]interface{}{
+		"n": 1, "length": 3, "min": 0, "max": 15, "base": 16,
+	}, &hex)
+	require.Len(t, hex[0], 3)
+
+	req, err := svc.NewRequest("generateIntegerSequences", map[string]interface{}{
+		"n": 3, "length": []int{1, 2}, "min": 0, "max": 9,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.EqualError(t, err, "sequence params must have a single value or one per sequence: n is 3")
+}
+
+func TestLocal_Errors(t *testing.T) {
+	svc := backend.NewPool(newPool(t, []byte{1}))
+
+	req, err := svc.NewRequest("generateSignedIntegers", map[string]int{"n": 1})
+	require.NoError(t, err)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, backend.ErrUnsupportedMethod)
+
+	req, err = svc.NewRequest("generateIntegers", map[string]interface{}{"n": 1, "min": 0, "max": 255, "base": 3})
+	require.NoError(t, err)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, backend.ErrUnsupportedParams)
+
+	req, err = svc.NewRequest("generateUUIDs", map[string]int{"n": 1})
+	require.NoError(t, err)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, pool.ErrPoolExhausted)
+
+	usage, err := svc.GetUsage(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), usage.BitsLeft)
+
+	_, err = backend.NewCrypto().GetUsage(context.Background(), "")
+	require.ErrorIs(t, err, backend.ErrUsageUnavailable)
+}
+
+func TestParseName(t *testing.T) {
+	name, err := backend.ParseName("Crypto")
+	require.NoError(t, err)
+	require.Equal(t, backend.Crypto, name)
+
+	_, err = backend.ParseName("dice")
+	require.ErrorIs(t, err, backend.ErrUnknownBackend)
+}
@@ -0,0 +1,57 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/backend"
+)
+
+// seededCases cover every option of generator commands. Expected values are
+// in testdata/seeded.json and must never change: fixtures depend on them.
+var seededCases = []struct {
+	name   string
+	method string
+	params string
+}{
+	{"integers", "generateIntegers", `{"n":10,"min":1,"max":100,"replacement":true,"base":10}`},
+	{"integers unique", "generateIntegers", `{"n":10,"min":1,"max":10,"replacement":false,"base":10}`},
+	{"integers base 16", "generateIntegers", `{"n":5,"min":0,"max":65535,"replacement":true,"base":16}`},
+	{"integers base 2", "generateIntegers", `{"n":5,"min":-8,"max":8,"replacement":true,"base":2}`},
+	{"coin", "generateIntegers", `{"n":8,"min":0,"max":1,"replacement":true,"base":10}`},
+	{"decimals", "generateDecimalFractions", `{"n":5,"decimalPlaces":4,"replacement":true}`},
+	{"decimals unique", "generateDecimalFractions", `{"n":5,"decimalPlaces":1,"replacement":false}`},
+	{"gaussians", "generateGaussians", `{"n":5,"mean":10,"standardDeviation":2.5,"significantDigits":6}`},
+	{"gaussians 2 digits", "generateGaussians", `{"n":5,"mean":0,"standardDeviation":1,"significantDigits":2}`},
+	{"strings", "generateStrings", `{"n":3,"length":12,"characters":"abcdefghijklmnopqrstuvwxyz0123456789","replacement":true}`},
+	{"strings unicode unique", "generateStrings", `{"n":4,"length":1,"characters":"αβγδ","replacement":false}`},
+	{"uuids", "generateUUIDs", `{"n":2}`},
+	{"blobs hex", "generateBlobs", `{"n":2,"size":64,"format":"hex"}`},
+	{"blobs base64", "generateBlobs", `{"n":1,"size":128,"format":"base64"}`},
+}
+
+func TestSeeded_Golden(t *testing.T) {
+	data, err := os.ReadFile("./testdata/seeded.json")
+	require.NoError(t, err)
+
+	var expected map[string]json.RawMessage
+
+	require.NoError(t, json.Unmarshal(data, &expected))
+	require.Len(t, expected, len(seededCases))
+
+	for _, tc := range seededCases {
+		svc := backend.NewSeeded("fixtures")
+
+		req, err := svc.NewRequest(tc.method, json.RawMessage(tc.params))
+		require.NoError(t, err, tc.name)
+
+		result, err := svc.ExecuteRequest(context.Background(), &req)
+		require.NoError(t, err, tc.name)
+
+		require.JSONEq(t, string(expected[tc.name]), string(result.Random.Data), tc.name)
+	}
+}
@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+)
+
+const errSequenceParams = entities.Error("sequence params must have a single value or one per sequence")
+
+// sequencesParams of generateIntegerSequences. Every param except n is given
+// either as a single value for all sequences or as array with value per sequence.
+type sequencesParams struct {
+	Number      int              `json:"n"`
+	Length      listParam[int]   `json:"length"`
+	Min         listParam[int64] `json:"min"`
+	Max         listParam[int64] `json:"max"`
+	Replacement listParam[bool]  `json:"replacement"`
+	Base        listParam[int]   `json:"base"`
+}
+
+func sequencesFunc(rawParams json.RawMessage, values *interface{}) (func(io.Reader) error, error) {
+	p := sequencesParams{
+		Replacement: listParam[bool]{true},
+		Base:        listParam[int]{10}, // nolint: gomnd
+	}
+
+	if err := json.Unmarshal(rawParams, &p); err != nil {
+		return nil, fmt.Errorf("decode params: %w", err)
+	}
+
+	for _, l := range []int{len(p.Length), len(p.Min), len(p.Max), len(p.Replacement), len(p.Base)} {
+		if l != 1 && l != p.Number {
+			return nil, fmt.Errorf("%w: n is %d", errSequenceParams, p.Number)
+		}
+	}
+
+	formats := make([]func([]int64) interface{}, p.Number)
+
+	for i := range formats {
+		format, err := integerFormat(p.Base.at(i))
+		if err != nil {
+			return nil, err
+		}
+
+		formats[i] = format
+	}
+
+	return func(r io.Reader) error {
+		seqs := make([]interface{}, 0, p.Number)
+
+		for i := range p.Number {
+			ints, err := derive.Integers(r, p.Length.at(i), p.Min.at(i), p.Max.at(i), p.Replacement.at(i))
+			if err != nil {
+				return err // nolint: wrapcheck
+			}
+
+			seqs = append(seqs, formats[i](ints))
+		}
+
+		*values = seqs
+
+		return nil
+	}, nil
+}
+
+// listParam decodes a single value as list of one value.
+type listParam[T any] []T
+
+func (l *listParam[T]) UnmarshalJSON(data []byte) error {
+	var one T
+
+	if err := json.Unmarshal(data, &one); err == nil {
+		*l = listParam[T]{one}
+
+		return nil
+	}
+
+	var many []T
+
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err // nolint: wrapcheck
+	}
+
+	*l = many
+
+	return nil
+}
+
+// at returns value of i-th sequence.
+func (l listParam[T]) at(i int) T {
+	if len(l) == 1 {
+		return l[0]
+	}
+
+	return l[i]
+}
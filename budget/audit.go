@@ -0,0 +1,63 @@
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	filePerm = 0o600
+	dirPerm  = 0o700
+)
+
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	KeyHash   string    `json:"key"`
+	User      string    `json:"user"`
+	Command   string    `json:"command"`
+	Method    string    `json:"method"`
+	Rule      Rule      `json:"rule"`
+	Violation string    `json:"violation"`
+	Reason    string    `json:"reason"`
+}
+
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// AuditLog is an append-only JSONL log of budget overrides.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (l *AuditLog) Append(entry AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), dirPerm); err != nil {
+		return fmt.Errorf("create audit log dir: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerm)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+const (
+	ErrBudgetExceeded = entities.Error("budget exceeded")
+	ErrInvalidRule    = entities.Error("invalid budget rule")
+)
+
+type Period string
+
+const (
+	PeriodHour Period = "hour"
+	PeriodDay  Period = "day"
+)
+
+func (p Period) Duration() time.Duration {
+	switch p {
+	case PeriodHour:
+		return time.Hour
+	case PeriodDay:
+		return 24 * time.Hour // nolint: gomnd
+	default:
+		return 0
+	}
+}
+
+type Scope string
+
+const (
+	// ScopeUser counts consumption of the current OS user only.
+	ScopeUser Scope = "user"
+	// ScopeKey counts consumption of every user sharing the api key.
+	ScopeKey Scope = "key"
+)
+
+// Rule limits consumption within a rolling period. Empty User or Command
+// means the rule applies to every user or command.
+type Rule struct {
+	User        string `json:"user,omitempty"`
+	Command     string `json:"command,omitempty"`
+	Scope       Scope  `json:"scope,omitempty"`
+	Period      Period `json:"period"`
+	MaxBits     uint64 `json:"maxBits,omitempty"`
+	MaxRequests uint64 `json:"maxRequests,omitempty"`
+}
+
+func (r Rule) Validate() error {
+	if r.Period.Duration() == 0 {
+		return fmt.Errorf("%w: period must be one of %q, %q", ErrInvalidRule, PeriodHour, PeriodDay)
+	}
+
+	if r.Scope != "" && r.Scope != ScopeUser && r.Scope != ScopeKey {
+		return fmt.Errorf("%w: scope must be one of %q, %q", ErrInvalidRule, ScopeUser, ScopeKey)
+	}
+
+	if r.MaxBits == 0 && r.MaxRequests == 0 {
+		return fmt.Errorf("%w: either maxBits or maxRequests is required", ErrInvalidRule)
+	}
+
+	return nil
+}
+
+func (r Rule) String() string {
+	bb, _ := json.Marshal(r)
+
+	return string(bb)
+}
+
+func (r Rule) appliesTo(user, command string) bool {
+	return (r.User == "" || r.User == user) && (r.Command == "" || r.Command == command)
+}
+
+func (r Rule) counts(e ledger.Entry, user string, since time.Time) bool {
+	if e.Timestamp.Before(since) {
+		return false
+	}
+
+	if r.Command != "" && e.Command != r.Command {
+		return false
+	}
+
+	return r.Scope == ScopeKey || e.User == user
+}
+
+// Violation describes a rule that would be broken by one more request.
+type Violation struct {
+	Rule     Rule
+	Bits     uint64
+	Requests uint64
+}
+
+func (v Violation) String() string {
+	who := "user"
+	if v.Rule.Scope == ScopeKey {
+		who = "api key"
+	}
+
+	if v.Rule.Command != "" {
+		who += " " + v.Rule.Command
+	}
+
+	what := fmt.Sprintf("%d/%d requests", v.Requests, v.Rule.MaxRequests)
+	if v.Rule.MaxBits > 0 && v.Bits >= v.Rule.MaxBits {
+		what = fmt.Sprintf("%d/%d bits", v.Bits, v.Rule.MaxBits)
+	}
+
+	return fmt.Sprintf("%s used %s per %s", who, what, v.Rule.Period)
+}
+
+// Check returns the first rule violated if user makes one more request of command.
+// Bits of the upcoming request are unknown beforehand, so bit limits
+// deny requests once consumption reached the limit.
+func Check(rules []Rule, entries []ledger.Entry, user, command string, now time.Time) *Violation {
+	for _, r := range rules {
+		if !r.appliesTo(user, command) {
+			continue
+		}
+
+		var (
+			since = now.Add(-r.Period.Duration())
+			v     = Violation{Rule: r}
+		)
+
+		for _, e := range entries {
+			if r.counts(e, user, since) {
+				v.Requests++
+				v.Bits += e.BitsUsed
+			}
+		}
+
+		if (r.MaxRequests > 0 && v.Requests >= r.MaxRequests) || (r.MaxBits > 0 && v.Bits >= r.MaxBits) {
+			return &v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package budget_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+func TestRuleValidate(t *testing.T) {
+	testcases := []struct {
+		name        string
+		rule        budget.Rule
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			rule: budget.Rule{Period: budget.PeriodDay, MaxBits: 10},
+		},
+		{
+			name:        "invalid period",
+			rule:        budget.Rule{Period: "week", MaxBits: 10},
+			expectedErr: `invalid budget rule: period must be one of "hour", "day"`,
+		},
+		{
+			name:        "invalid scope",
+			rule:        budget.Rule{Period: budget.PeriodHour, Scope: "team", MaxBits: 10},
+			expectedErr: `invalid budget rule: scope must be one of "user", "key"`,
+		},
+		{
+			name:        "no limits",
+			rule:        budget.Rule{Period: budget.PeriodHour},
+			expectedErr: "invalid budget rule: either maxBits or maxRequests is required",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := tc.rule.Validate()
+
+		if tc.expectedErr == "" {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.EqualError(t, err, tc.expectedErr, tc.name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2022, 8, 25, 12, 0, 0, 0, time.UTC)
+
+	entries := []ledger.Entry{
+		{Timestamp: now.Add(-30 * time.Hour), User: "alice", Command: "blob", BitsUsed: 100000},
+		{Timestamp: now.Add(-2 * time.Hour), User: "alice", Command: "blob", BitsUsed: 4000},
+		{Timestamp: now.Add(-30 * time.Minute), User: "alice", Command: "blob", BitsUsed: 4000},
+		{Timestamp: now.Add(-10 * time.Minute), User: "bob", Command: "blob", BitsUsed: 4000},
+		{Timestamp: now.Add(-5 * time.Minute), User: "bob", Command: "integer", BitsUsed: 10},
+	}
+
+	testcases := []struct {
+		name     string
+		rule     budget.Rule
+		user     string
+		command  string
+		expected *budget.Violation
+	}{
+		{
+			name:    "user bits per day within budget",
+			rule:    budget.Rule{Period: budget.PeriodDay, MaxBits: 8001},
+			user:    "alice",
+			command: "integer",
+		},
+		{
+			name:    "user bits per day exceeded",
+			rule:    budget.Rule{Period: budget.PeriodDay, MaxBits: 8000},
+			user:    "alice",
+			command: "integer",
+			expected: &budget.Violation{
+				Rule:     budget.Rule{Period: budget.PeriodDay, MaxBits: 8000},
+				Bits:     8000,
+				Requests: 2,
+			},
+		},
+		{
+			name:    "key blob requests per hour exceeded",
+			rule:    budget.Rule{Command: "blob", Scope: budget.ScopeKey, Period: budget.PeriodHour, MaxRequests: 2},
+			user:    "carol",
+			command: "blob",
+			expected: &budget.Violation{
+				Rule:     budget.Rule{Command: "blob", Scope: budget.ScopeKey, Period: budget.PeriodHour, MaxRequests: 2},
+				Bits:     8000,
+				Requests: 2,
+			},
+		},
+		{
+			name:    "command rule does not apply to other commands",
+			rule:    budget.Rule{Command: "blob", Scope: budget.ScopeKey, Period: budget.PeriodHour, MaxRequests: 2},
+			user:    "carol",
+			command: "integer",
+		},
+		{
+			name:    "user rule does not apply to other users",
+			rule:    budget.Rule{User: "bob", Period: budget.PeriodHour, MaxRequests: 1},
+			user:    "alice",
+			command: "blob",
+		},
+	}
+
+	for _, tc := range testcases {
+		v := budget.Check([]budget.Rule{tc.rule}, entries, tc.user, tc.command, now)
+		require.Equal(t, tc.expected, v, tc.name)
+	}
+}
+
+func TestViolationString(t *testing.T) {
+	v := budget.Violation{
+		Rule:     budget.Rule{Command: "blob", Scope: budget.ScopeKey, Period: budget.PeriodHour, MaxRequests: 2},
+		Requests: 2,
+	}
+	require.Equal(t, "api key blob used 2/2 requests per hour", v.String())
+
+	v = budget.Violation{
+		Rule: budget.Rule{Period: budget.PeriodDay, MaxBits: 100, MaxRequests: 20},
+		Bits: 120,
+	}
+	require.Equal(t, "user used 120/100 bits per day", v.String())
+}
@@ -0,0 +1,103 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+var _ services.RandRetiever = (*Enforcer)(nil)
+
+func NewEnforcer(
+	next services.RandRetiever,
+	rules []Rule,
+	l *ledger.Ledger,
+	audit *AuditLog,
+	user, command, overrideReason string,
+) *Enforcer {
+	return &Enforcer{
+		next:           next,
+		rules:          rules,
+		ledger:         l,
+		audit:          audit,
+		user:           user,
+		command:        command,
+		overrideReason: overrideReason,
+		now:            time.Now,
+	}
+}
+
+// Enforcer is a RandRetiever that refuses to execute requests once
+// ledger recorded consumption reaches a configured budget.
+type Enforcer struct {
+	next           services.RandRetiever
+	rules          []Rule
+	ledger         *ledger.Ledger
+	audit          *AuditLog
+	user           string
+	command        string
+	overrideReason string
+	now            func() time.Time
+}
+
+func (svc *Enforcer) NewRequest(method string, params services.RandParameters) (entities.RandomRequest, error) {
+	return svc.next.NewRequest(method, params) // nolint: wrapcheck
+}
+
+func (svc *Enforcer) ExecuteRequest(
+	ctx context.Context,
+	randReq *entities.RandomRequest,
+) (entities.RandResponseResult, error) {
+	if err := svc.check(randReq); err != nil {
+		return entities.RandResponseResult{}, err
+	}
+
+	return svc.next.ExecuteRequest(ctx, randReq) // nolint: wrapcheck
+}
+
+func (svc *Enforcer) GetUsage(ctx context.Context, apiKey string) (entities.UsageStatus, error) {
+	return svc.next.GetUsage(ctx, apiKey) // nolint: wrapcheck
+}
+
+func (svc *Enforcer) check(randReq *entities.RandomRequest) error {
+	keyHash := ledger.HashKey(gjson.GetBytes(randReq.Params, "apiKey").String())
+
+	entries, err := svc.ledger.Entries(func(e ledger.Entry) bool { return e.KeyHash == keyHash })
+	if err != nil {
+		return fmt.Errorf("read usage ledger: %w", err)
+	}
+
+	now := svc.now().UTC()
+
+	violation := Check(svc.rules, entries, svc.user, svc.command, now)
+	if violation == nil {
+		return nil
+	}
+
+	if svc.overrideReason == "" {
+		return fmt.Errorf("%w: %s. Use --budget-override with a reason to bypass", ErrBudgetExceeded, violation)
+	}
+
+	entry := AuditEntry{
+		Timestamp: now,
+		KeyHash:   keyHash,
+		User:      svc.user,
+		Command:   svc.command,
+		Method:    randReq.Method,
+		Rule:      violation.Rule,
+		Violation: violation.String(),
+		Reason:    svc.overrideReason,
+	}
+
+	if err := svc.audit.Append(entry); err != nil {
+		return fmt.Errorf("budget override not audited: %w", err)
+	}
+
+	return nil
+}
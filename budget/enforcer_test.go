@@ -0,0 +1,124 @@
+package budget_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+const apiKey = "c6418ada-7874-4907-9367-f43c446686d3" // nolint: gosec
+
+func newLedger(t *testing.T) *ledger.Ledger {
+	t.Helper()
+
+	l := ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl"))
+
+	require.NoError(t, l.Append(ledger.Entry{
+		Timestamp: time.Now().UTC().Add(-time.Minute),
+		KeyHash:   ledger.HashKey(apiKey),
+		User:      "alice",
+		Command:   "blob",
+		BitsUsed:  1000,
+	}))
+
+	return l
+}
+
+func testRequest() entities.RandomRequest {
+	return entities.RandomRequest{
+		ID:             uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		JsonrpcVersion: "2.0",
+		Method:         "generateBlobs",
+		Params:         json.RawMessage(`{"apiKey":"` + apiKey + `"}`),
+	}
+}
+
+func TestEnforcer_WithinBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := testRequest()
+	rules := []budget.Rule{{Period: budget.PeriodDay, MaxBits: 1001}}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockRandRetriever.EXPECT().
+		ExecuteRequest(gomock.Any(), &req).
+		Return(testutils.TestRandResult(t, `["AA=="]`), nil)
+
+	svc := budget.NewEnforcer(mockRandRetriever, rules, newLedger(t), nil, "alice", "blob", "")
+
+	_, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+}
+
+func TestEnforcer_Exceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := testRequest()
+	rules := []budget.Rule{{Command: "blob", Period: budget.PeriodHour, MaxRequests: 1}}
+
+	svc := budget.NewEnforcer(mock.NewMockRandRetiever(ctrl), rules, newLedger(t), nil, "alice", "blob", "")
+
+	_, err := svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, budget.ErrBudgetExceeded)
+	require.EqualError(t, err,
+		"budget exceeded: user blob used 1/1 requests per hour. Use --budget-override with a reason to bypass")
+}
+
+func TestEnforcer_Override(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		req       = testRequest()
+		rules     = []budget.Rule{{Period: budget.PeriodDay, MaxBits: 500}}
+		auditPath = filepath.Join(t.TempDir(), "audit.jsonl")
+	)
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockRandRetriever.EXPECT().
+		ExecuteRequest(gomock.Any(), &req).
+		Return(testutils.TestRandResult(t, `["AA=="]`), nil)
+
+	svc := budget.NewEnforcer(
+		mockRandRetriever, rules, newLedger(t), budget.NewAuditLog(auditPath), "alice", "blob", "release keys",
+	)
+
+	_, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+
+	f, err := os.Open(auditPath)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+
+	var entry budget.AuditEntry
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+	require.False(t, scanner.Scan())
+
+	require.Equal(t, ledger.HashKey(apiKey), entry.KeyHash)
+	require.Equal(t, "alice", entry.User)
+	require.Equal(t, "blob", entry.Command)
+	require.Equal(t, "generateBlobs", entry.Method)
+	require.Equal(t, rules[0], entry.Rule)
+	require.Equal(t, "user used 1000/500 bits per day", entry.Violation)
+	require.Equal(t, "release keys", entry.Reason)
+}
@@ -0,0 +1,123 @@
+// Package choose draws weighted items without bias. Item with weight w
+// out of total W occupies w consecutive integers of [0, W), and an
+// integer v uniform in [0, RangeMax) selects item covering v mod W when
+// v is below the largest multiple of W not exceeding RangeMax. Greater
+// values are rejected, so every integer of [0, W) is equally likely.
+package choose
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidItem = entities.Error("invalid item")
+	ErrNoItems     = entities.Error("no items to choose from")
+
+	// RangeMax is size of range random integers are drawn from.
+	RangeMax = 1_000_000_000
+	// WeightMax is the largest total weight of items.
+	WeightMax = RangeMax
+	ItemsMax  = 10_000
+
+	weightSep    = ":"
+	weightHeader = "weight"
+)
+
+type Item struct {
+	Name   string
+	Weight int
+}
+
+// ParseItem parses NAME:WEIGHT, NAME alone has weight 1. Name may contain colons,
+// weight is after the last one.
+func ParseItem(s string) (Item, error) {
+	name, weight := s, "1"
+
+	if i := strings.LastIndex(s, weightSep); i >= 0 {
+		name, weight = s[:i], s[i+1:]
+	}
+
+	return newItem(name, weight)
+}
+
+// ReadCSV reads NAME,WEIGHT rows. Rows without weight have weight 1 and
+// header row with "weight" column is skipped.
+func ReadCSV(r io.Reader) ([]Item, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var items []Item
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		weight := "1"
+		if len(record) > 1 {
+			weight = record[1]
+		}
+
+		if row == 1 && strings.EqualFold(strings.TrimSpace(weight), weightHeader) {
+			continue
+		}
+
+		item, err := newItem(record[0], weight)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		items = append(items, item)
+	}
+}
+
+func newItem(name, weight string) (Item, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Item{}, fmt.Errorf("%w: empty name", ErrInvalidItem)
+	}
+
+	w, err := strconv.Atoi(strings.TrimSpace(weight))
+	if err != nil || w < 1 || w > WeightMax {
+		return Item{}, fmt.Errorf("%w: %q weight must be integer in [1, %d]", ErrInvalidItem, name, WeightMax)
+	}
+
+	return Item{Name: name, Weight: w}, nil
+}
+
+// Validate checks number of items and their total weight.
+func Validate(items []Item) error {
+	switch {
+	case len(items) == 0:
+		return ErrNoItems
+	case len(items) > ItemsMax:
+		return fmt.Errorf("%w: at most %d items", ErrInvalidItem, ItemsMax)
+	case TotalWeight(items) > WeightMax:
+		return fmt.Errorf("%w: total weight exceeds %d", ErrInvalidItem, WeightMax)
+	}
+
+	return nil
+}
+
+func TotalWeight(items []Item) int {
+	total := 0
+
+	for _, item := range items {
+		total += item.Weight
+	}
+
+	return total
+}
@@ -0,0 +1,126 @@
+package choose_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/choose"
+)
+
+func TestParseItem(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected choose.Item
+	}{
+		{s: "alice:3", expected: choose.Item{Name: "alice", Weight: 3}},
+		{s: "bob", expected: choose.Item{Name: "bob", Weight: 1}},
+		{s: "host:8080:2", expected: choose.Item{Name: "host:8080", Weight: 2}},
+	}
+
+	for _, tc := range testCases {
+		item, err := choose.ParseItem(tc.s)
+		require.NoError(t, err, tc.s)
+		require.Equal(t, tc.expected, item)
+	}
+
+	for _, s := range []string{"", ":3", "alice:", "alice:0", "alice:-1", "alice:x", "alice:1000000001"} {
+		_, err := choose.ParseItem(s)
+		require.ErrorIs(t, err, choose.ErrInvalidItem, s)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	items, err := choose.ReadCSV(strings.NewReader("name,weight\nalice, 3\n\"smith, bob\",1\ncarol\n"))
+	require.NoError(t, err)
+	require.Equal(t, []choose.Item{
+		{Name: "alice", Weight: 3},
+		{Name: "smith, bob", Weight: 1},
+		{Name: "carol", Weight: 1},
+	}, items)
+
+	_, err = choose.ReadCSV(strings.NewReader("alice,3\nbob,x\n"))
+	require.EqualError(t, err, `row 2: invalid item: "bob" weight must be integer in [1, 1000000000]`)
+}
+
+func ints(t *testing.T, batches ...[]int) choose.Ints {
+	t.Helper()
+
+	return func(n int) ([]int, error) {
+		require.NotEmpty(t, batches, "unexpected call")
+		require.Len(t, batches[0], n)
+
+		values := batches[0]
+		batches = batches[1:]
+
+		return values, nil
+	}
+}
+
+func TestDraw(t *testing.T) {
+	items := []choose.Item{{Name: "alice", Weight: 3}, {Name: "bob", Weight: 1}, {Name: "carol", Weight: 3}}
+
+	// limit for total 7 is 999999994, for total 4 it's 1000000000
+	res, err := choose.Draw(items, 2, false, ints(t,
+		[]int{999_999_994, 12},
+		[]int{999_999_999},
+	))
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 1}, res.Winners)
+	require.Equal(t, []choose.Step{
+		{Value: 999_999_994, Total: 7, Limit: 999_999_994, Index: -1},
+		{Value: 12, Total: 7, Limit: 999_999_994, Index: 2},
+		{Value: 999_999_999, Total: 4, Limit: 1_000_000_000, Index: 1},
+	}, res.Steps)
+
+	var sb strings.Builder
+
+	require.NoError(t, res.Explain(&sb))
+	require.Equal(t, `Weights (total 7):
+  1    alice            3          [0, 3)
+  2    bob              1          [3, 4)
+  3    carol            3          [4, 7)
+Values in [0, 1000000000), value v selects v mod total if v < limit, chosen items are removed:
+  999999994  limit 999999994  rejected
+  12         limit 999999994  12 mod 7 = 5 -> carol
+  999999999  limit 1000000000 999999999 mod 4 = 3 -> bob
+`, sb.String())
+}
+
+func TestDraw_Replace(t *testing.T) {
+	items := []choose.Item{{Name: "alice", Weight: 3}, {Name: "bob", Weight: 1}}
+
+	res, err := choose.Draw(items, 3, true, ints(t, []int{0, 7, 5}))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 0}, res.Winners)
+}
+
+func TestDraw_Invalid(t *testing.T) {
+	items := []choose.Item{{Name: "alice", Weight: 3}, {Name: "bob", Weight: 1}}
+
+	_, err := choose.Draw(nil, 1, false, ints(t))
+	require.ErrorIs(t, err, choose.ErrNoItems)
+
+	_, err = choose.Draw(items, 3, false, ints(t))
+	require.ErrorIs(t, err, choose.ErrInvalidPick)
+
+	_, err = choose.Draw(items, 0, true, ints(t))
+	require.ErrorIs(t, err, choose.ErrInvalidPick)
+
+	_, err = choose.Draw(items, 1, false, ints(t, []int{choose.RangeMax}))
+	require.ErrorIs(t, err, choose.ErrInvalidValue)
+
+	heavy := []choose.Item{{Name: "a", Weight: choose.WeightMax}, {Name: "b", Weight: 1}}
+	_, err = choose.Draw(heavy, 1, false, ints(t))
+	require.ErrorIs(t, err, choose.ErrInvalidItem)
+}
+
+func TestDraw_TooManyRejections(t *testing.T) {
+	items := []choose.Item{{Name: "a", Weight: 600_000_000}}
+
+	_, err := choose.Draw(items, 1, false, func(int) ([]int, error) {
+		return []int{999_999_999}, nil
+	})
+	require.ErrorIs(t, err, choose.ErrTooManyRejections)
+}
@@ -0,0 +1,152 @@
+package choose
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidPick       = entities.Error("invalid pick")
+	ErrInvalidValue      = entities.Error("random value out of range")
+	ErrTooManyRejections = entities.Error("too many rejected values")
+
+	// RoundMax limits requests for values replacing rejected ones.
+	RoundMax = 100
+)
+
+// Ints returns n integers uniform in [0, RangeMax).
+type Ints func(n int) ([]int, error)
+
+// Step is a random value used by draw.
+type Step struct {
+	Value int
+	// Total weight of items left before the step.
+	Total int
+	// Limit is the largest multiple of Total not exceeding RangeMax, greater values are rejected.
+	Limit int
+	// Index of chosen item, -1 if value is rejected.
+	Index int
+}
+
+func (s Step) Accepted() bool {
+	return s.Index >= 0
+}
+
+type Result struct {
+	Items   []Item
+	Replace bool
+	// Winners are indices of chosen items in order of draw.
+	Winners []int
+	Steps   []Step
+}
+
+// Draw chooses pick items proportionally to their weights. Without replace
+// chosen item is removed and following values select from items left.
+func Draw(items []Item, pick int, replace bool, ints Ints) (Result, error) {
+	if err := Validate(items); err != nil {
+		return Result{}, err
+	}
+
+	if pick < 1 || pick > ItemsMax || (!replace && pick > len(items)) {
+		return Result{}, fmt.Errorf("%w: %d of %d items", ErrInvalidPick, pick, len(items))
+	}
+
+	res := Result{Items: items, Replace: replace}
+
+	left := make([]int, len(items))
+	for i := range left {
+		left[i] = i
+	}
+
+	total := TotalWeight(items)
+
+	for round := 0; len(res.Winners) < pick; round++ {
+		if round == RoundMax {
+			return Result{}, ErrTooManyRejections
+		}
+
+		values, err := ints(pick - len(res.Winners))
+		if err != nil {
+			return Result{}, err
+		}
+
+		for _, v := range values {
+			if v < 0 || v >= RangeMax {
+				return Result{}, fmt.Errorf("%w: %d", ErrInvalidValue, v)
+			}
+
+			step := Step{Value: v, Total: total, Limit: RangeMax - RangeMax%total, Index: -1}
+
+			if v < step.Limit {
+				pos := locate(items, left, v%total)
+				step.Index = left[pos]
+				res.Winners = append(res.Winners, step.Index)
+
+				if !replace {
+					total -= items[step.Index].Weight
+					left = append(left[:pos], left[pos+1:]...)
+				}
+			}
+
+			res.Steps = append(res.Steps, step)
+		}
+	}
+
+	return res, nil
+}
+
+// locate returns position in left of item covering r in cumulative weights of left items.
+func locate(items []Item, left []int, r int) int {
+	for pos, i := range left {
+		if r < items[i].Weight {
+			return pos
+		}
+
+		r -= items[i].Weight
+	}
+
+	panic("choose: value exceeds total weight")
+}
+
+// Explain writes cumulative weight table and every random value with its
+// outcome, so the draw can be repeated by hand.
+func (r Result) Explain(w io.Writer) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Weights (total %d):\n", TotalWeight(r.Items))
+
+	from := 0
+
+	for i, item := range r.Items {
+		fmt.Fprintf(&sb, "  %-4d %-16s %-10d [%d, %d)\n", i+1, item.Name, item.Weight, from, from+item.Weight)
+		from += item.Weight
+	}
+
+	fmt.Fprintf(&sb, "Values in [0, %d), value v selects v mod total if v < limit", RangeMax)
+
+	if !r.Replace {
+		sb.WriteString(", chosen items are removed")
+	}
+
+	sb.WriteString(":\n")
+
+	for _, s := range r.Steps {
+		if !s.Accepted() {
+			fmt.Fprintf(&sb, "  %-10d limit %-10d rejected\n", s.Value, s.Limit)
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, "  %-10d limit %-10d %d mod %d = %d -> %s\n",
+			s.Value, s.Limit, s.Value, s.Total, s.Value%s.Total, r.Items[s.Index].Name)
+	}
+
+	if _, err := fmt.Fprint(w, sb.String()); err != nil {
+		return fmt.Errorf("write explanation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	blobsMethod       = "generateBlobs"
+	signedBlobsMethod = "generateSignedBlobs"
+	sizeMax           = 1_048_576
+
+	hexFormat    = "hex"
+	base64Format = "base64"
+)
+
+// BlobParams requests Number blobs of Size bits. Hex only changes encoding
+// used on the wire, blobs are returned decoded either way. Signed blobs come
+// with serial number and signature in Meta.
+type BlobParams struct {
+	Size   int64
+	Number int
+	Hex    bool
+	Signed bool
+}
+
+func (p *BlobParams) Validate() error {
+	const (
+		errSizeDivisibleBy8 = entities.Error("`size` parameter must be divisible by 8")
+		errSizeExceeded     = entities.Error("size exceed")
+	)
+
+	if err := validation.Validate(
+		p.Size,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(sizeMax),
+	); err != nil {
+		return fmt.Errorf("`size` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Number,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(numberMax),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	if p.Size%8 != 0 {
+		return errSizeDivisibleBy8
+	}
+
+	if totalSize := p.Size * int64(p.Number); totalSize > sizeMax {
+		return fmt.Errorf("%w: %d > %d", errSizeExceeded, totalSize, sizeMax)
+	}
+
+	return nil
+}
+
+func (c *Client) Blobs(ctx context.Context, params BlobParams) ([][]byte, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var (
+		data    []string
+		method  = blobsMethod
+		format  = base64Format
+		decoder = base64.StdEncoding.DecodeString
+	)
+
+	if params.Hex {
+		format, decoder = hexFormat, hex.DecodeString
+	}
+
+	if params.Signed {
+		method = signedBlobsMethod
+	}
+
+	meta, err := c.call(ctx, method, blobRequest{
+		APIKey:     c.apiKey,
+		Size:       params.Size,
+		Number:     params.Number,
+		Format:     format,
+		PregenRand: c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	blobs := make([][]byte, 0, len(data))
+
+	for _, v := range data {
+		blob, err := decoder(v)
+		if err != nil {
+			return nil, Meta{}, fmt.Errorf("decode random data: %w", err)
+		}
+
+		blobs = append(blobs, blob)
+	}
+
+	return blobs, meta, nil
+}
+
+type blobRequest struct {
+	APIKey     string              `json:"apiKey"`
+	Size       int64               `json:"size"`
+	Number     int                 `json:"n"`
+	Format     string              `json:"format"`
+	PregenRand entities.PregenRand `json:"pregeneratedRandomization"`
+}
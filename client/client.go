@@ -0,0 +1,91 @@
+// Package client is Go API of random.org generators. Commands of randapi are
+// built on it, so params are validated the same way as on command line.
+//
+//	c := client.NewRandomOrg(apiKey, nil)
+//	dice, meta, err := c.Integers(ctx, client.IntegerParams{From: 1, To: 6, Number: 3})
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+const numberMax = 10_000
+
+// Meta describes the call values came from.
+type Meta = entities.APIInfo
+
+type Client struct {
+	apiKey     string
+	retriever  services.RandRetiever
+	pregenRand entities.PregenRand
+}
+
+// New returns client getting values with retriever: random.org retriever, local
+// backend or one wrapped with ledger and budgets.
+func New(apiKey string, retriever services.RandRetiever) *Client {
+	return &Client{
+		apiKey:    apiKey,
+		retriever: retriever,
+	}
+}
+
+// NewRandomOrg returns client of random.org API. Nil httpClient means http.DefaultClient.
+func NewRandomOrg(apiKey string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return New(apiKey, randapi.NewRandomOrgRetriever(randapi.DefaultAPIPath, httpClient, false))
+}
+
+// SetPregenRand makes following calls use pregenerated randomization, so the
+// same params return the same values.
+func (c *Client) SetPregenRand(pregenRand entities.PregenRand) {
+	c.pregenRand = pregenRand
+}
+
+// Usage returns usage status of the client api key.
+func (c *Client) Usage(ctx context.Context) (entities.UsageStatus, error) {
+	status, err := c.retriever.GetUsage(ctx, c.apiKey)
+	if err != nil {
+		return entities.UsageStatus{}, fmt.Errorf("get usage: %w", err)
+	}
+
+	return status, nil
+}
+
+// call executes method and decodes random data into data.
+func (c *Client) call(ctx context.Context, method string, params services.RandParameters, data interface{}) (Meta, error) {
+	req, err := c.retriever.NewRequest(method, params)
+	if err != nil {
+		return Meta{}, fmt.Errorf("create request: %w", err)
+	}
+
+	result, err := c.retriever.ExecuteRequest(ctx, &req)
+	if err != nil {
+		return Meta{}, fmt.Errorf("get result: %w", err)
+	}
+
+	if err := json.Unmarshal(result.Random.Data, data); err != nil {
+		return Meta{}, fmt.Errorf("decode result: %w", err)
+	}
+
+	return Meta{
+		ID:           req.ID,
+		Timestamp:    time.Time(result.Random.Timestamp),
+		RequestsLeft: result.RequestsLeft,
+		BitsUsed:     result.BitsUsed,
+		BitsLeft:     result.BitsLeft,
+		Source:       result.Source,
+		SerialNumber: result.Random.SerialNumber,
+		Signature:    result.Signature,
+	}, nil
+}
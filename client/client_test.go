@@ -0,0 +1,180 @@
+package client_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/randapi/fakeserver"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+const apiKey = "6b81b415-80e9-4481-a5f1-58e354742c00" //nolint: gosec
+
+var signingKey = sync.OnceValue(func() *rsa.PrivateKey { // nolint: gochecknoglobals
+	key, err := rsa.GenerateKey(rand.Reader, 1024) // nolint: gosec
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+})
+
+func newClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	fs, err := fakeserver.New(fakeserver.Options{SigningKey: signingKey()})
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(fs)
+	t.Cleanup(ts.Close)
+
+	return client.New(apiKey, randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false))
+}
+
+func TestClient(t *testing.T) {
+	var (
+		c   = newClient(t)
+		ctx = context.Background()
+	)
+
+	ints, meta, err := c.Integers(ctx, client.IntegerParams{From: 1, To: 5, Number: 4, Unique: true})
+	require.NoError(t, err)
+	require.Len(t, ints, 4)
+
+	for _, v := range ints {
+		require.True(t, v >= 1 && v <= 5, v)
+	}
+	require.Equal(t, randapi.SourceName, meta.Source)
+	require.NotZero(t, meta.BitsUsed)
+	require.Equal(t, uint64(fakeserver.DefaultRequests-1), meta.RequestsLeft)
+
+	decimals, _, err := c.Decimals(ctx, client.DecimalParams{Base: 10, Places: 2, Number: 3})
+	require.NoError(t, err)
+	require.Len(t, decimals, 3)
+
+	for _, v := range decimals {
+		require.True(t, v >= 0 && v < 10, v)
+	}
+
+	gaussians, _, err := c.Gaussians(ctx, client.GaussianParams{Deviation: 1, SignificantDigits: 4, Number: 2})
+	require.NoError(t, err)
+	require.Len(t, gaussians, 2)
+
+	strs, _, err := c.Strings(ctx, client.StringParams{Length: 8, Charset: "xyz", Number: 2})
+	require.NoError(t, err)
+	require.Len(t, strs, 2)
+	require.Regexp(t, "^[xyz]{8}$", strs[0])
+
+	uuids, _, err := c.UUIDs(ctx, client.UUIDParams{Number: 2})
+	require.NoError(t, err)
+	require.Len(t, uuids, 2)
+	require.NotEqual(t, uuids[0], uuids[1])
+
+	blobs, _, err := c.Blobs(ctx, client.BlobParams{Size: 128, Number: 2, Hex: true})
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	require.Len(t, blobs[1], 16)
+
+	seqs, _, err := c.IntegerSequences(ctx, []client.SequenceParams{
+		{Length: 5, From: 1, To: 5, Unique: true},
+		{Length: 2, From: 1, To: 12},
+	})
+	require.NoError(t, err)
+	require.Len(t, seqs, 2)
+	require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, seqs[0])
+	require.Len(t, seqs[1], 2)
+
+	usage, err := c.Usage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(fakeserver.DefaultRequests-7), usage.RequestsLeft)
+}
+
+func TestClient_PregenRand(t *testing.T) {
+	var (
+		c      = newClient(t)
+		ctx    = context.Background()
+		params = client.StringParams{Length: 10, Charset: "abcdef", Number: 3}
+		id     = "draw-1"
+	)
+
+	c.SetPregenRand(entities.PregenRand{ID: &id})
+
+	first, _, err := c.Strings(ctx, params)
+	require.NoError(t, err)
+
+	second, _, err := c.Strings(ctx, params)
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+}
+
+func TestClient_InvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// invalid params are rejected before any request is made
+	c := client.New(apiKey, mock.NewMockRandRetiever(ctrl))
+	ctx := context.Background()
+
+	_, _, err := c.Integers(ctx, client.IntegerParams{From: 6, To: 1, Number: 1})
+	require.EqualError(t, err, "`from` param must be less than `to`: from 6 to 1")
+
+	_, _, err = c.Decimals(ctx, client.DecimalParams{Places: 2, Number: 1})
+	require.EqualError(t, err, "`base` param is invalid: cannot be blank")
+
+	_, _, err = c.Gaussians(ctx, client.GaussianParams{SignificantDigits: 1, Number: 1})
+	require.EqualError(t, err, "`signdig` param is invalid: must be no less than 2")
+
+	_, _, err = c.Strings(ctx, client.StringParams{Length: 2, Charset: "ab", Number: 5, Unique: true})
+	require.EqualError(t, err, "`number` of unique requested values is greater than possible with max possible 4")
+
+	_, _, err = c.UUIDs(ctx, client.UUIDParams{Number: 10_001})
+	require.EqualError(t, err, "`number` param is invalid: must be no greater than 10000")
+
+	_, _, err = c.Blobs(ctx, client.BlobParams{Size: 12, Number: 1})
+	require.EqualError(t, err, "`size` parameter must be divisible by 8")
+
+	_, _, err = c.IntegerSequences(ctx, []client.SequenceParams{
+		{Length: 5, From: 1, To: 50, Unique: true},
+		{Length: 3, From: 1, To: 2, Unique: true},
+	})
+	require.EqualError(t, err, "sequence 2: `number` of unique requested values is greater than possible in range 1 - 2")
+
+	_, _, err = c.IntegerSequences(ctx, nil)
+	require.EqualError(t, err, "number of sequences is invalid: must be no less than 1")
+
+	_, _, err = c.IntegerSequences(ctx, []client.SequenceParams{
+		{Length: 6_000, From: 1, To: 10}, {Length: 6_000, From: 1, To: 10},
+	})
+	require.EqualError(t, err, "total length of sequences is greater than possible: 12000 > 10000")
+}
+
+func TestClient_RetrieveFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	retriever := mock.NewMockRandRetiever(ctrl)
+
+	gomock.InOrder(
+		retriever.EXPECT().
+			NewRequest("generateUUIDs", gomock.Any()).
+			Return(entities.RandomRequest{}, nil),
+		retriever.EXPECT().
+			ExecuteRequest(gomock.Any(), gomock.Any()).
+			Return(entities.RandResponseResult{}, entities.Error("test error")),
+	)
+
+	_, _, err := client.New(apiKey, retriever).UUIDs(context.Background(), client.UUIDParams{Number: 1})
+	require.ErrorIs(t, err, entities.Error("test error"))
+}
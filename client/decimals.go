@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/shopspring/decimal"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	decimalsMethod   = "generateDecimalFractions"
+	decimalPlacesMax = 14
+)
+
+// DecimalParams requests Number fractions in [0, 1) with Places decimal places,
+// multiplied by Base. Base 1 returns fractions as is.
+type DecimalParams struct {
+	Base   float64
+	Places int
+	Number int
+	Unique bool
+}
+
+func (p *DecimalParams) Validate() error {
+	const errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
+
+	if err := validation.Validate(p.Base, validation.Required); err != nil {
+		return fmt.Errorf("`base` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Places, validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(decimalPlacesMax),
+	); err != nil {
+		return fmt.Errorf("`places` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Number, validation.Min(1),
+		validation.Max(numberMax),
+		validation.Required.Error("must be no less than 1"),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	if p.Number > int(math.Pow10(p.Places)) {
+		return fmt.Errorf("%w decimal places = %d", errMaxUniqueRandomExceeded, p.Places)
+	}
+
+	return nil
+}
+
+func (c *Client) Decimals(ctx context.Context, params DecimalParams) ([]float64, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var data []float64
+
+	meta, err := c.call(ctx, decimalsMethod, decimalRequest{
+		APIKey:        c.apiKey,
+		Number:        params.Number,
+		DecimalPlaces: params.Places,
+		Replacement:   !params.Unique,
+		PregenRand:    c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	base := decimal.NewFromFloat(params.Base)
+
+	for i, v := range data {
+		data[i], _ = base.Mul(decimal.NewFromFloat(v)).Float64()
+	}
+
+	return data, meta, nil
+}
+
+type decimalRequest struct {
+	APIKey        string              `json:"apiKey"`
+	Number        int                 `json:"n"`
+	DecimalPlaces int                 `json:"decimalPlaces"`
+	Replacement   bool                `json:"replacement"`
+	PregenRand    entities.PregenRand `json:"pregeneratedRandomization"`
+}
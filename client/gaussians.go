@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	gaussiansMethod      = "generateGaussians"
+	gaussianRangeMaxMin  = 1_000_000
+	minSignificantDigits = 2
+	maxSignificantDigits = 14
+)
+
+// GaussianParams requests Number values from normal distribution rounded to SignificantDigits.
+type GaussianParams struct {
+	Mean              float64
+	Deviation         float64
+	SignificantDigits int
+	Number            int
+}
+
+func (p *GaussianParams) Validate() error {
+	if err := validation.Validate(
+		p.Mean,
+		validation.Min(float64(-gaussianRangeMaxMin)),
+		validation.Max(float64(gaussianRangeMaxMin)),
+	); err != nil {
+		return fmt.Errorf("`mean` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Deviation,
+		validation.Min(float64(-gaussianRangeMaxMin)),
+		validation.Max(float64(gaussianRangeMaxMin)),
+	); err != nil {
+		return fmt.Errorf("`deviation` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.SignificantDigits,
+		validation.Required.Error("must be no less than 2"),
+		validation.Min(minSignificantDigits),
+		validation.Max(maxSignificantDigits),
+	); err != nil {
+		return fmt.Errorf("`signdig` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Number, validation.Min(1),
+		validation.Max(numberMax),
+		validation.Required.Error("must be no less than 1"),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) Gaussians(ctx context.Context, params GaussianParams) ([]float64, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var data []float64
+
+	meta, err := c.call(ctx, gaussiansMethod, gaussianRequest{
+		APIKey:            c.apiKey,
+		Mean:              params.Mean,
+		StandardDeviation: params.Deviation,
+		SignificantDigits: params.SignificantDigits,
+		Number:            params.Number,
+		PregenRand:        c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return data, meta, nil
+}
+
+type gaussianRequest struct {
+	APIKey            string              `json:"apiKey"`
+	Mean              float64             `json:"mean"`
+	StandardDeviation float64             `json:"standardDeviation"`
+	SignificantDigits int                 `json:"significantDigits"`
+	Number            int                 `json:"n"`
+	PregenRand        entities.PregenRand `json:"pregeneratedRandomization"`
+}
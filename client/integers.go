@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	integersMethod = "generateIntegers"
+	rangeMaxMin    = 1_000_000_000
+	intBase        = 10
+)
+
+// IntegerParams requests Number integers in [From, To].
+type IntegerParams struct {
+	From   int64
+	To     int64
+	Number int
+	Unique bool
+}
+
+func (p *IntegerParams) Validate() error {
+	const (
+		errToBiggerThanFrom        = entities.Error("`from` param must be less than `to`")
+		errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
+	)
+
+	if err := validation.Validate(p.From, validation.Min(-rangeMaxMin), validation.Max(rangeMaxMin)); err != nil {
+		return fmt.Errorf("`from` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(p.To, validation.Min(-rangeMaxMin), validation.Max(rangeMaxMin)); err != nil {
+		return fmt.Errorf("`to` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Number,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(numberMax),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	if p.From >= p.To {
+		return fmt.Errorf("%w: from %d to %d", errToBiggerThanFrom, p.From, p.To)
+	}
+
+	if (p.To-p.From+1) < int64(p.Number) && p.Unique {
+		return fmt.Errorf("%w in range %d - %d", errMaxUniqueRandomExceeded, p.From, p.To)
+	}
+
+	return nil
+}
+
+func (c *Client) Integers(ctx context.Context, params IntegerParams) ([]int, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var data []int
+
+	meta, err := c.call(ctx, integersMethod, integerRequest{
+		APIKey:      c.apiKey,
+		Number:      params.Number,
+		Min:         params.From,
+		Max:         params.To,
+		Replacement: !params.Unique,
+		Base:        intBase,
+		PregenRand:  c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return data, meta, nil
+}
+
+type integerRequest struct {
+	APIKey      string              `json:"apiKey"`
+	Number      int                 `json:"n"`
+	Min         int64               `json:"min"`
+	Max         int64               `json:"max"`
+	Replacement bool                `json:"replacement"`
+	Base        int8                `json:"base"`
+	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
+}
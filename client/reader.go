@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const ErrNoData = entities.Error("no random data in result")
+
+const (
+	// MaxChunk is the largest number of bytes fetched in one generateBlobs call.
+	MaxChunk = sizeMax / bitsInByte
+	// DefaultChunk is number of bytes Reader fetches at once by default.
+	DefaultChunk = 4096
+
+	bitsInByte = 8
+)
+
+var _ io.Reader = (*Reader)(nil)
+
+// NewReader returns reader of random bytes fetched with generateBlobs in
+// chunks of chunkSize bytes, DefaultChunk if chunkSize is not positive and
+// at most MaxChunk. Calls are paced by the retriever, random.org retriever
+// waits advisory delay between calls. Reads fail once ctx is done.
+func (c *Client) NewReader(ctx context.Context, chunkSize int) *Reader {
+	switch {
+	case chunkSize <= 0:
+		chunkSize = DefaultChunk
+	case chunkSize > MaxChunk:
+		chunkSize = MaxChunk
+	}
+
+	return &Reader{
+		ctx:    ctx,
+		client: c,
+		chunk:  chunkSize,
+	}
+}
+
+// Reader is io.Reader of random bytes. It is safe for concurrent use.
+type Reader struct {
+	ctx    context.Context // nolint: containedctx
+	client *Client
+	chunk  int
+
+	mu   sync.Mutex
+	buf  []byte
+	meta Meta
+}
+
+// Read fills p from buffered bytes, fetching next chunk when buffer is empty.
+// It may read less than len(p) bytes, use io.ReadFull to fill p completely.
+func (r *Reader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.buf) == 0 {
+		if err := r.fetch(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// Meta describes the last call made by reader.
+func (r *Reader) Meta() Meta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.meta
+}
+
+func (r *Reader) fetch() error {
+	if err := r.ctx.Err(); err != nil {
+		return fmt.Errorf("read random bytes: %w", err)
+	}
+
+	blobs, meta, err := r.client.Blobs(r.ctx, BlobParams{
+		Size:   int64(r.chunk) * bitsInByte,
+		Number: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("read random bytes: %w", err)
+	}
+
+	if len(blobs) == 0 || len(blobs[0]) == 0 {
+		return fmt.Errorf("read random bytes: %w", ErrNoData)
+	}
+
+	r.buf = blobs[0]
+	r.meta = meta
+
+	return nil
+}
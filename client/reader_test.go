@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/client"
+)
+
+func TestReader(t *testing.T) {
+	r := newClient(t).NewReader(context.Background(), 1000)
+
+	data := make([]byte, 2500)
+
+	_, err := io.ReadFull(r, data)
+	require.NoError(t, err)
+	require.NotEqual(t, make([]byte, len(data)), data)
+	require.Equal(t, uint64(8000), r.Meta().BitsUsed)
+
+	n, err := r.Read(make([]byte, 1000))
+	require.NoError(t, err)
+	require.Equal(t, 500, n, "the rest of the last chunk is read before next call")
+}
+
+func TestReader_Seeded(t *testing.T) {
+	read := func(chunk, n int) []byte {
+		c := client.New("", backend.NewSeeded("stream"))
+
+		data, err := io.ReadAll(io.LimitReader(c.NewReader(context.Background(), chunk), int64(n)))
+		require.NoError(t, err)
+		require.Len(t, data, n)
+
+		return data
+	}
+
+	// seeded bytes don't depend on chunking
+	require.Equal(t, read(client.MaxChunk+1, 300_000), read(0, 300_000))
+}
+
+func TestReader_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := newClient(t).NewReader(ctx, 16)
+
+	_, err := r.Read(make([]byte, 8))
+	require.NoError(t, err)
+
+	cancel()
+
+	// buffered bytes are still returned
+	_, err = r.Read(make([]byte, 8))
+	require.NoError(t, err)
+
+	_, err = r.Read(make([]byte, 8))
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	integerSequencesMethod = "generateIntegerSequences"
+	sequencesMax           = 1_000
+)
+
+// SequenceParams requests a sequence of Length integers in [From, To].
+// Unique integers don't repeat within the sequence.
+type SequenceParams struct {
+	Length int
+	From   int64
+	To     int64
+	Unique bool
+}
+
+func (p *SequenceParams) Validate() error {
+	ints := IntegerParams{From: p.From, To: p.To, Number: p.Length, Unique: p.Unique}
+
+	return ints.Validate()
+}
+
+// ValidateSequences checks every sequence and limits of a single call: at most
+// 1,000 sequences and 10,000 integers in total.
+func ValidateSequences(seqs []SequenceParams) error {
+	const errTooManyIntegers = entities.Error("total length of sequences is greater than possible")
+
+	if err := validation.Validate(
+		len(seqs),
+		validation.Required.Error("must be no less than 1"),
+		validation.Max(sequencesMax),
+	); err != nil {
+		return fmt.Errorf("number of sequences is invalid: %w", err)
+	}
+
+	total := 0
+
+	for i := range seqs {
+		if err := seqs[i].Validate(); err != nil {
+			return fmt.Errorf("sequence %d: %w", i+1, err)
+		}
+
+		total += seqs[i].Length
+	}
+
+	if total > numberMax {
+		return fmt.Errorf("%w: %d > %d", errTooManyIntegers, total, numberMax)
+	}
+
+	return nil
+}
+
+// IntegerSequences returns sequences drawn in a single call, in order of seqs.
+func (c *Client) IntegerSequences(ctx context.Context, seqs []SequenceParams) ([][]int, Meta, error) {
+	if err := ValidateSequences(seqs); err != nil {
+		return nil, Meta{}, err
+	}
+
+	req := sequencesRequest{
+		APIKey:      c.apiKey,
+		Number:      len(seqs),
+		Length:      make([]int, 0, len(seqs)),
+		Min:         make([]int64, 0, len(seqs)),
+		Max:         make([]int64, 0, len(seqs)),
+		Replacement: make([]bool, 0, len(seqs)),
+		Base:        intBase,
+		PregenRand:  c.pregenRand,
+	}
+
+	for _, s := range seqs {
+		req.Length = append(req.Length, s.Length)
+		req.Min = append(req.Min, s.From)
+		req.Max = append(req.Max, s.To)
+		req.Replacement = append(req.Replacement, !s.Unique)
+	}
+
+	var data [][]int
+
+	meta, err := c.call(ctx, integerSequencesMethod, req, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return data, meta, nil
+}
+
+type sequencesRequest struct {
+	APIKey      string              `json:"apiKey"`
+	Number      int                 `json:"n"`
+	Length      []int               `json:"length"`
+	Min         []int64             `json:"min"`
+	Max         []int64             `json:"max"`
+	Replacement []bool              `json:"replacement"`
+	Base        int8                `json:"base"`
+	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
+}
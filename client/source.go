@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	mrand "math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Exhaustion decides what Source does when buffered bytes are spent and
+// the next chunk can't be fetched, e.g. quota is exceeded or network is down.
+type Exhaustion int
+
+const (
+	// ExhaustionPanic panics with the fetch error. Use it when values must
+	// come from random.org and failure has to stop the computation.
+	ExhaustionPanic Exhaustion = iota
+	// ExhaustionBlock retries with growing delay until fetch succeeds.
+	// It panics once Source context is done.
+	ExhaustionBlock
+	// ExhaustionFallback reads crypto/rand until the next fetch succeeds.
+	// After a failed fetch the next one is tried only when a growing delay
+	// passes, values in between come from crypto/rand without fetching.
+	ExhaustionFallback
+)
+
+const (
+	uint64Bytes     = 8
+	retryBackoff    = 100 * time.Millisecond
+	retryBackoffMax = 10 * time.Second
+)
+
+var _ mrand.Source = (*Source)(nil)
+
+// NewSource returns math/rand/v2 source of values read with NewReader(ctx, chunkSize).
+// Use it as rand.New(src).
+func (c *Client) NewSource(ctx context.Context, chunkSize int, exhaustion Exhaustion) *Source {
+	return &Source{
+		ctx:        ctx,
+		r:          c.NewReader(ctx, chunkSize),
+		exhaustion: exhaustion,
+	}
+}
+
+// Source is math/rand/v2 Source of random.org bytes. It is safe for concurrent use,
+// unlike rand.Rand wrapping it, which needs its own locking.
+type Source struct {
+	ctx        context.Context // nolint: containedctx
+	r          *Reader
+	exhaustion Exhaustion
+
+	mu        sync.Mutex
+	err       error
+	fallbacks int
+	// retryAt and backoff delay fetches after ExhaustionFallback triggers.
+	retryAt time.Time
+	backoff time.Duration
+}
+
+func (s *Source) Uint64() uint64 {
+	var b [uint64Bytes]byte
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.read(b[:])
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Err returns the last error fetching values, nil if the last fetch succeeded.
+func (s *Source) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Fallbacks returns how many values were read from crypto/rand by ExhaustionFallback.
+func (s *Source) Fallbacks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fallbacks
+}
+
+func (s *Source) read(b []byte) {
+	if s.exhaustion == ExhaustionFallback && time.Now().Before(s.retryAt) {
+		s.fallback(b)
+
+		return
+	}
+
+	backoff := retryBackoff
+
+	for {
+		_, err := io.ReadFull(s.r, b)
+
+		s.err = err
+		if err == nil {
+			s.backoff = 0
+
+			return
+		}
+
+		switch s.exhaustion {
+		case ExhaustionFallback:
+			s.backoff = min(max(s.backoff*2, retryBackoff), retryBackoffMax) // nolint: gomnd
+			s.retryAt = time.Now().Add(s.backoff)
+			s.fallback(b)
+
+			return
+		case ExhaustionBlock:
+			if s.wait(backoff) {
+				backoff = min(backoff*2, retryBackoffMax) // nolint: gomnd
+
+				continue
+			}
+		}
+
+		panic(fmt.Errorf("random source: %w", err))
+	}
+}
+
+func (s *Source) fallback(b []byte) {
+	s.fallbacks++
+	_, _ = rand.Read(b)
+}
+
+// wait sleeps for d and reports whether the source may still be used.
+func (s *Source) wait(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
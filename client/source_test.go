@@ -0,0 +1,185 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	mrand "math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestSource(t *testing.T) {
+	src := newClient(t).NewSource(context.Background(), 64, client.ExhaustionPanic)
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// rand.Rand isn't safe for concurrent use, every goroutine has its own
+			r := mrand.New(src) // nolint: gosec
+
+			for range 50 {
+				if v := r.IntN(6); v < 0 || v >= 6 {
+					t.Errorf("value %d is out of range", v)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.NoError(t, src.Err())
+	require.Zero(t, src.Fallbacks())
+}
+
+// failingRetriever fails the first failures calls and then returns bytes 1..8.
+func failingRetriever(t *testing.T, ctrl *gomock.Controller, failures int) *mock.MockRandRetiever {
+	t.Helper()
+
+	retriever := mock.NewMockRandRetiever(ctrl)
+
+	retriever.EXPECT().
+		NewRequest("generateBlobs", gomock.Any()).
+		Return(entities.RandomRequest{}, nil).
+		AnyTimes()
+
+	calls := []*gomock.Call{}
+
+	for range failures {
+		calls = append(calls, retriever.EXPECT().
+			ExecuteRequest(gomock.Any(), gomock.Any()).
+			Return(entities.RandResponseResult{}, entities.Error("test error")))
+	}
+
+	calls = append(calls, retriever.EXPECT().
+		ExecuteRequest(gomock.Any(), gomock.Any()).
+		Return(testutils.TestRandResult(t, `["AQIDBAUGBwg="]`), nil).
+		AnyTimes())
+
+	gomock.InOrder(calls...)
+
+	return retriever
+}
+
+func TestSource_ExhaustionPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := client.New("", failingRetriever(t, ctrl, 1))
+	src := c.NewSource(context.Background(), 8, client.ExhaustionPanic)
+
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		require.ErrorIs(t, err, entities.Error("test error"))
+		require.ErrorIs(t, src.Err(), entities.Error("test error"))
+	}()
+
+	src.Uint64()
+	t.Fatal("expected panic")
+}
+
+func TestSource_ExhaustionBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := client.New("", failingRetriever(t, ctrl, 2))
+	src := c.NewSource(context.Background(), 8, client.ExhaustionBlock)
+
+	require.Equal(t, uint64(0x0807060504030201), src.Uint64())
+	require.NoError(t, src.Err())
+}
+
+func TestSource_ExhaustionBlockCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := client.New("", failingRetriever(t, ctrl, 0))
+	src := c.NewSource(ctx, 8, client.ExhaustionBlock)
+
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		require.True(t, errors.Is(err, context.Canceled), err)
+	}()
+
+	src.Uint64()
+	t.Fatal("expected panic")
+}
+
+func TestSource_ExhaustionFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := client.New("", failingRetriever(t, ctrl, 1))
+	src := c.NewSource(context.Background(), 8, client.ExhaustionFallback)
+
+	src.Uint64()
+	require.Equal(t, 1, src.Fallbacks())
+	require.ErrorIs(t, src.Err(), entities.Error("test error"))
+
+	time.Sleep(150 * time.Millisecond)
+
+	require.Equal(t, uint64(0x0807060504030201), src.Uint64())
+	require.NoError(t, src.Err())
+	require.Equal(t, 1, src.Fallbacks())
+}
+
+func TestSource_ExhaustionFallbackBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var calls atomic.Int32
+
+	retriever := mock.NewMockRandRetiever(ctrl)
+	retriever.EXPECT().
+		NewRequest("generateBlobs", gomock.Any()).
+		Return(entities.RandomRequest{}, nil).
+		AnyTimes()
+	retriever.EXPECT().
+		ExecuteRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *entities.RandomRequest) (entities.RandResponseResult, error) {
+			calls.Add(1)
+
+			return entities.RandResponseResult{}, entities.Error("test error")
+		}).
+		AnyTimes()
+
+	src := client.New("", retriever).NewSource(context.Background(), 8, client.ExhaustionFallback)
+
+	for range 100 {
+		src.Uint64()
+	}
+
+	// values after a failed fetch don't call random.org until backoff passes
+	require.Equal(t, int32(1), calls.Load())
+	require.Equal(t, 100, src.Fallbacks())
+
+	time.Sleep(150 * time.Millisecond)
+
+	for range 100 {
+		src.Uint64()
+	}
+
+	// the second failure doubles backoff
+	require.Equal(t, int32(2), calls.Load())
+	require.Equal(t, 200, src.Fallbacks())
+	require.ErrorIs(t, src.Err(), entities.Error("test error"))
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	stringsMethod = "generateStrings"
+	maxStringLen  = 32
+	maxCharsetLen = 128
+)
+
+// StringParams requests Number strings of Length characters from Charset.
+type StringParams struct {
+	Length  int
+	Charset string
+	Number  int
+	Unique  bool
+}
+
+func (p *StringParams) Validate() error {
+	const errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
+
+	if err := validation.Validate(
+		p.Length,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(maxStringLen),
+	); err != nil {
+		return fmt.Errorf("`length` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		len(p.Charset),
+		validation.Required.Error("length must be no less than 1"),
+		validation.Max(maxCharsetLen),
+	); err != nil {
+		return fmt.Errorf("`charset` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Number,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(numberMax),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	if p.Unique {
+		possibleRand := int(math.Pow(float64(len(p.Charset)), float64(p.Length)))
+		if possibleRand < p.Number {
+			return fmt.Errorf("%w with max possible %d", errMaxUniqueRandomExceeded, possibleRand)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Strings(ctx context.Context, params StringParams) ([]string, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var data []string
+
+	meta, err := c.call(ctx, stringsMethod, stringRequest{
+		APIKey:      c.apiKey,
+		Length:      params.Length,
+		Characters:  params.Charset,
+		Number:      params.Number,
+		Replacement: !params.Unique,
+		PregenRand:  c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return data, meta, nil
+}
+
+type stringRequest struct {
+	APIKey      string              `json:"apiKey"`
+	Length      int                 `json:"length"`
+	Characters  string              `json:"characters"`
+	Number      int                 `json:"n"`
+	Replacement bool                `json:"replacement"`
+	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const uuidsMethod = "generateUUIDs"
+
+// UUIDParams requests Number version 4 UUIDs.
+type UUIDParams struct {
+	Number int
+}
+
+func (p *UUIDParams) Validate() error {
+	if err := validation.Validate(
+		p.Number,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(numberMax),
+	); err != nil {
+		return fmt.Errorf("`number` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) UUIDs(ctx context.Context, params UUIDParams) ([]uuid.UUID, Meta, error) {
+	if err := params.Validate(); err != nil {
+		return nil, Meta{}, err
+	}
+
+	var data []uuid.UUID
+
+	meta, err := c.call(ctx, uuidsMethod, uuidRequest{
+		APIKey:     c.apiKey,
+		Number:     params.Number,
+		PregenRand: c.pregenRand,
+	}, &data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return data, meta, nil
+}
+
+type uuidRequest struct {
+	APIKey     string              `json:"apiKey"`
+	Number     int                 `json:"n"`
+	PregenRand entities.PregenRand `json:"pregeneratedRandomization"`
+}
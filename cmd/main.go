@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -8,20 +9,43 @@ import (
 	"os"
 	"time"
 
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/budget"
 	"github.com/bohdanch-w/rand-api/cmd/tools/blob"
+	choosecmd "github.com/bohdanch-w/rand-api/cmd/tools/choose"
 	"github.com/bohdanch-w/rand-api/cmd/tools/coin"
 	"github.com/bohdanch-w/rand-api/cmd/tools/decimal"
+	dicecmd "github.com/bohdanch-w/rand-api/cmd/tools/dice"
+	fakecmd "github.com/bohdanch-w/rand-api/cmd/tools/fakeserver"
+	gwcmd "github.com/bohdanch-w/rand-api/cmd/tools/gateway"
 	"github.com/bohdanch-w/rand-api/cmd/tools/gausian"
+	groupscmd "github.com/bohdanch-w/rand-api/cmd/tools/groups"
 	"github.com/bohdanch-w/rand-api/cmd/tools/integer"
+	lotterycmd "github.com/bohdanch-w/rand-api/cmd/tools/lottery"
+	pairscmd "github.com/bohdanch-w/rand-api/cmd/tools/pairs"
+	passphrasecmd "github.com/bohdanch-w/rand-api/cmd/tools/passphrase"
+	passwordcmd "github.com/bohdanch-w/rand-api/cmd/tools/password"
+	poolcmd "github.com/bohdanch-w/rand-api/cmd/tools/pool"
+	rafflecmd "github.com/bohdanch-w/rand-api/cmd/tools/raffle"
+	"github.com/bohdanch-w/rand-api/cmd/tools/serve"
+	"github.com/bohdanch-w/rand-api/cmd/tools/shuffle"
 	"github.com/bohdanch-w/rand-api/cmd/tools/status"
+	"github.com/bohdanch-w/rand-api/cmd/tools/stream"
 	randstr "github.com/bohdanch-w/rand-api/cmd/tools/string"
+	"github.com/bohdanch-w/rand-api/cmd/tools/usage"
 	"github.com/bohdanch-w/rand-api/cmd/tools/uuid"
 	"github.com/bohdanch-w/rand-api/cmd/tools/version"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 	"github.com/bohdanch-w/rand-api/internal/build"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/metrics"
 	"github.com/bohdanch-w/rand-api/output"
+	"github.com/bohdanch-w/rand-api/pool"
+	"github.com/bohdanch-w/rand-api/quota"
 	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/recording"
+	"github.com/bohdanch-w/rand-api/tracing"
 
 	guuid "github.com/google/uuid"
 	"github.com/urfave/cli/v2"
@@ -39,10 +63,26 @@ const (
 	timeoutParam    = "timeout"
 	separatorParam  = "separator"
 	outputParam     = "file"
+	ledgerParam     = "ledger"
+	configParam     = "config"
+	overrideParam   = "budget-override"
+	warnParam       = "warn-threshold"
+	metricsParam    = "metrics-file"
+	traceParam      = "trace"
+	traceEndpoint   = "trace-endpoint"
+	traceFileParam  = "trace-file"
+	backendParam    = "backend"
+	poolParam       = "pool"
+	seedParam       = "seed"
+	fallbackParam   = "fallback"
+	recordParam     = "record"
+	replayParam     = "replay"
 
-	defaultTimeout     = 5 * time.Second
-	defaultSeparator   = " "
-	defaultRandAPIPath = "https://api.random.org/json-rpc/4/invoke"
+	// exitFallback is returned when some values were generated by fallback.
+	exitFallback = 3
+
+	defaultTimeout   = 5 * time.Second
+	defaultSeparator = " "
 )
 
 func retriveParamsFunc(cfg *config.AppConfig, f **os.File) cli.BeforeFunc { // nolint: funlen
@@ -82,6 +122,7 @@ func retriveParamsFunc(cfg *config.AppConfig, f **os.File) cli.BeforeFunc { // n
 		}
 
 		cfg.Timeout = c.Duration(timeoutParam)
+		cfg.Signed = c.Bool(signedParam)
 
 		var (
 			w   io.Writer = os.Stdout
@@ -97,33 +138,290 @@ func retriveParamsFunc(cfg *config.AppConfig, f **os.File) cli.BeforeFunc { // n
 			w = *f
 		}
 
-		cfg.OutputProcessor = output.NewOutputProcessor(
+		cfg.Output = w
+
+		outputProcessor := output.NewOutputProcessor(
 			c.Bool(verboseParam),
 			c.Bool(quietParam),
 			c.String(separatorParam),
 			w,
 		)
+		cfg.OutputProcessor = outputProcessor
 
-		cfg.RandRetriever = randapi.NewRandomOrgRetriever(
-			c.String(apiPathParam),
-			http.DefaultClient,
-			c.Bool(signedParam),
-		)
+		fileCfg, err := config.LoadFile(c.String(configParam))
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		cfg.File = fileCfg
+
+		if err := setupRetriever(c, cfg); err != nil {
+			return err
+		}
+
+		if _, ok := cfg.RandRetriever.(*backend.Local); ok {
+			// local backends don't spend api key quota
+			return nil
+		}
+
+		warner, err := newWarner(c, cfg)
+		if err != nil {
+			return err
+		}
+
+		outputProcessor.SetWarner(warner)
 
 		return nil
 	}
 }
 
+func newWarner(c *cli.Context, cfg *config.AppConfig) (*quota.Warner, error) {
+	warnCfg := cfg.File.Warnings
+
+	if thresholds := c.Float64Slice(warnParam); len(thresholds) > 0 {
+		warnCfg.Thresholds = thresholds
+	}
+
+	if err := warnCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", warnParam, err)
+	}
+
+	ttl := time.Duration(warnCfg.LimitsTTL)
+	if ttl == 0 {
+		ttl = quota.DefaultLimitsTTL
+	}
+
+	cachePath, err := quota.DefaultCachePath()
+	if err != nil {
+		cachePath = ""
+	}
+
+	limits := quota.NewLimitsCache(cachePath, ttl, cfg.RandRetriever.GetUsage)
+
+	return quota.NewWarner(warnCfg, cfg.APIKey, limits, cfg.Timeout), nil
+}
+
+func setupRetriever(c *cli.Context, cfg *config.AppConfig) error {
+	const (
+		errBudgetsNeedLedger = entities.Error("budgets require usage ledger to be enabled")
+		errPoolDisabled      = entities.Error("pool backend requires pool file")
+		errUnknownFallback   = entities.Error("unknown fallback")
+		errSeedNeedsSeeded   = entities.Error("seed is only used by seeded backend")
+	)
+
+	var (
+		user    = ledger.CurrentUser()
+		command = commandName(c)
+	)
+
+	if path := c.String(poolParam); path != "" {
+		cfg.Pool = pool.New(path)
+	}
+
+	name, err := backend.ParseName(c.String(backendParam))
+	if err != nil {
+		return fmt.Errorf("%s: %w", backendParam, err)
+	}
+
+	if c.IsSet(seedParam) {
+		if c.IsSet(backendParam) && name != backend.Seeded {
+			return errSeedNeedsSeeded
+		}
+
+		name = backend.Seeded
+	}
+
+	switch name {
+	case backend.RandomOrg:
+	case backend.Crypto:
+		cfg.RandRetriever = backend.NewCrypto()
+
+		return nil
+	case backend.Seeded:
+		cfg.RandRetriever = backend.NewSeeded(c.String(seedParam))
+
+		return nil
+	case backend.Pool:
+		if cfg.Pool == nil {
+			return errPoolDisabled
+		}
+
+		cfg.RandRetriever = backend.NewPool(cfg.Pool)
+
+		return nil
+	}
+
+	client, err := httpClient(c)
+	if err != nil {
+		return err
+	}
+
+	retriever := randapi.NewRandomOrgRetriever(
+		c.String(apiPathParam),
+		client,
+		c.Bool(signedParam),
+	)
+
+	retriever.SetObserver(cfg.Metrics)
+
+	cfg.RandRetriever = retriever
+	cfg.Forwarder = retriever
+
+	// replayed calls don't spend quota
+	replaying := c.String(replayParam) != ""
+
+	if path := c.String(ledgerParam); path != "" && !replaying {
+		cfg.Ledger = ledger.New(path)
+		cfg.RandRetriever = ledger.NewRecorder(cfg.RandRetriever, cfg.Ledger, user, command)
+	}
+
+	if len(cfg.File.Budgets) > 0 && !replaying {
+		if cfg.Ledger == nil {
+			return errBudgetsNeedLedger
+		}
+
+		cfg.RandRetriever = budget.NewEnforcer(
+			cfg.RandRetriever,
+			cfg.File.Budgets,
+			cfg.Ledger,
+			budget.NewAuditLog(cfg.File.AuditLog),
+			user,
+			command,
+			c.String(overrideParam),
+		)
+	}
+
+	switch fallback := c.String(fallbackParam); fallback {
+	case "":
+	case string(backend.Crypto):
+		cfg.RandRetriever = backend.NewFallback(cfg.RandRetriever, func(err error) {
+			if !c.Bool(quietParam) {
+				log.Printf("WARN: %s\n", err)
+				log.Printf("WARN: values are generated locally with crypto/rand\n")
+			}
+		})
+	default:
+		return fmt.Errorf("%w: %q", errUnknownFallback, fallback)
+	}
+
+	return nil
+}
+
+// httpClient records or replays random.org exchanges when requested.
+func httpClient(c *cli.Context) (*http.Client, error) {
+	const errRecordAndReplay = entities.Error("only record OR replay is allowed. Not both")
+
+	recordDir, replayDir := c.String(recordParam), c.String(replayParam)
+
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, errRecordAndReplay
+	case recordDir != "":
+		rec, err := recording.NewRecorder(recordDir, http.DefaultTransport)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", recordParam, err)
+		}
+
+		return &http.Client{Transport: rec}, nil
+	case replayDir != "":
+		rep, err := recording.NewReplayer(replayDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", replayParam, err)
+		}
+
+		return &http.Client{Transport: rep}, nil
+	default:
+		return http.DefaultClient, nil
+	}
+}
+
+func setupTracing(c *cli.Context, shutdown *tracing.ShutdownFunc) error {
+	exporter, err := tracing.ParseExporter(c.String(traceParam))
+	if err != nil {
+		return fmt.Errorf("%s: %w", traceParam, err)
+	}
+
+	*shutdown, err = tracing.Setup(tracing.Config{
+		Exporter: exporter,
+		Endpoint: c.String(traceEndpoint),
+		File:     c.String(traceFileParam),
+	})
+
+	return err // nolint: wrapcheck
+}
+
+func afterFunc(cfg *config.AppConfig, shutdownTracing *tracing.ShutdownFunc) cli.AfterFunc {
+	const shutdownTimeout = 5 * time.Second
+
+	return func(c *cli.Context) error {
+		if *shutdownTracing != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			if err := (*shutdownTracing)(ctx); err != nil {
+				log.Printf("WARN: %s\n", err)
+			}
+		}
+
+		path := c.String(metricsParam)
+		if path == "" {
+			return nil
+		}
+
+		return cfg.Metrics.WriteFile(path) // nolint: wrapcheck
+	}
+}
+
+func commandName(c *cli.Context) string {
+	name := c.Args().First()
+
+	if cmd := c.App.Command(name); cmd != nil {
+		return cmd.Name
+	}
+
+	return name
+}
+
+func defaultLedgerPath() string {
+	path, err := ledger.DefaultPath()
+	if err != nil {
+		return ""
+	}
+
+	return path
+}
+
+func defaultPoolPath() string {
+	path, err := pool.DefaultPath()
+	if err != nil {
+		return ""
+	}
+
+	return path
+}
+
+func defaultConfigPath() string {
+	path, err := config.DefaultFilePath()
+	if err != nil {
+		return ""
+	}
+
+	return path
+}
+
 func main() { // nolint: funlen
 	var (
-		cfg config.AppConfig
-		f   *os.File
+		cfg             config.AppConfig
+		f               *os.File
+		shutdownTracing tracing.ShutdownFunc
 	)
 
 	if len(build.APIKey) > 0 {
 		cfg.APIKey = string(build.APIKey)
 	}
 
+	cfg.Metrics = metrics.New()
+
 	app := &cli.App{
 		Name:  CommandName,
 		Usage: "cli program to retrieve values from random.org",
@@ -131,12 +429,12 @@ func main() { // nolint: funlen
 			&cli.BoolFlag{
 				Name:    signedParam,
 				Aliases: []string{"s"},
-				Usage:   "get signed reply from random.org",
+				Usage:   "get signed reply from random.org, used by pool fill",
 			},
 			&cli.StringFlag{
 				Name:  apiPathParam,
 				Usage: "random api path",
-				Value: defaultRandAPIPath,
+				Value: randapi.DefaultAPIPath,
 			},
 			&cli.StringFlag{
 				Name:        apikeyParam,
@@ -178,22 +476,115 @@ func main() { // nolint: funlen
 				Aliases: []string{"o"},
 				Usage:   "save output to specified file",
 			},
+			&cli.StringFlag{
+				Name:  ledgerParam,
+				Usage: "append-only usage ledger file. Empty value disables recording",
+				Value: defaultLedgerPath(),
+			},
+			&cli.StringFlag{
+				Name:  configParam,
+				Usage: "configuration file with budgets and other settings",
+				Value: defaultConfigPath(),
+			},
+			&cli.StringFlag{
+				Name:  overrideParam,
+				Usage: "bypass exceeded budget. Reason is required and written to the audit log",
+			},
+			&cli.Float64SliceFlag{
+				Name:        warnParam,
+				Usage:       "warn when percent of key quota left drops to threshold. May be repeated",
+				DefaultText: "5",
+			},
+			&cli.StringFlag{
+				Name:  recordParam,
+				Usage: "save every random.org request and response to directory, api key excluded",
+			},
+			&cli.StringFlag{
+				Name:  replayParam,
+				Usage: "answer requests with responses saved by --record instead of contacting random.org",
+			},
+			&cli.StringFlag{
+				Name:  metricsParam,
+				Usage: "write prometheus metrics to file for textfile collector after the run",
+			},
+			&cli.StringFlag{
+				Name:    backendParam,
+				Aliases: []string{"source"},
+				Usage:   "where values come from: randomorg, crypto, seeded or pool",
+				Value:   string(backend.RandomOrg),
+			},
+			&cli.StringFlag{
+				Name:  fallbackParam,
+				Usage: "generate values locally when random.org is unreachable: crypto. Exits with code 3",
+			},
+			&cli.StringFlag{
+				Name:  seedParam,
+				Usage: "generate reproducible values from seed without network (implies --backend seeded)",
+			},
+			&cli.StringFlag{
+				Name:  poolParam,
+				Usage: "entropy pool file filled by pool command",
+				Value: defaultPoolPath(),
+			},
+			&cli.StringFlag{
+				Name:  traceParam,
+				Usage: "export traces: otlp, stdout (written to stderr) or file. Disabled if empty",
+			},
+			&cli.StringFlag{
+				Name:    traceEndpoint,
+				Usage:   "OTLP/HTTP traces endpoint of the collector",
+				Value:   tracing.DefaultOTLPEndpoint,
+				EnvVars: []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"},
+			},
+			&cli.StringFlag{
+				Name:  traceFileParam,
+				Usage: "file spans are appended to by file exporter",
+				Value: "randapi-traces.jsonl",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if err := setupTracing(c, &shutdownTracing); err != nil {
+				return err
+			}
+
+			return retriveParamsFunc(&cfg, &f)(c)
 		},
-		Before: retriveParamsFunc(&cfg, &f),
+		After: afterFunc(&cfg, &shutdownTracing),
 		Commands: []*cli.Command{
 			integer.NewIntegerCommand(&cfg),
 			coin.NewCoinCommand(&cfg),
+			dicecmd.NewDiceCommand(&cfg),
 			decimal.NewDecimalCommand(&cfg),
 			gausian.NewGausianCommand(&cfg),
 			randstr.NewStringCommand(&cfg),
 			uuid.NewUUIDCommand(&cfg),
 			blob.NewBlobCommand(&cfg),
+			shuffle.NewShuffleCommand(&cfg),
+			choosecmd.NewChooseCommand(&cfg),
+			lotterycmd.NewLotteryCommand(&cfg),
+			rafflecmd.NewRaffleCommand(&cfg),
+			pairscmd.NewPairsCommand(&cfg),
+			groupscmd.NewGroupsCommand(&cfg),
+			passwordcmd.NewPasswordCommand(&cfg),
+			passphrasecmd.NewPassphraseCommand(&cfg),
 			status.NewStatusCommand(&cfg),
+			usage.NewUsageCommand(&cfg),
+			serve.NewServeCommand(&cfg),
+			gwcmd.NewGatewayCommand(&cfg),
+			poolcmd.NewPoolCommand(&cfg),
+			fakecmd.NewFakeServerCommand(&cfg),
+			stream.NewStreamCommand(&cfg),
 			version.NewVersionCommand(),
 		},
 	}
 
+	tracing.WrapCommands(app.Commands)
+
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
+
+	if fb, ok := cfg.RandRetriever.(*backend.Fallback); ok && fb.Used() {
+		os.Exit(exitFallback)
+	}
 }
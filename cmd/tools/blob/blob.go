@@ -2,15 +2,11 @@ package blob
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -21,16 +17,12 @@ const (
 	hexParam    = "hex"
 	numberParam = "number"
 
-	method    = "generateBlobs"
-	sizeMax   = 1_048_576
-	numberMax = 10_000
-
-	hexFormat    = "hex"
 	base64Format = "base64"
 )
 
-// nolint: gomnd
 func NewBlobCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:  CommandName,
 		Usage: "generate random Binary Large OBject. Total size must not exceed 1,048,576 bits (128 Kib)",
@@ -39,7 +31,7 @@ func NewBlobCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    sizeParam,
 				Usage:   "size of blobs in bits [1, 1048576] must be divisible by 8",
 				Aliases: []string{"s"},
-				Value:   64,
+				Value:   defaults.Size,
 			},
 			&cli.BoolFlag{
 				Name:        hexParam,
@@ -50,116 +42,46 @@ func NewBlobCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    numberParam,
 				Usage:   "number of values returned [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 		},
 		Action: blob(cfg),
 	}
 }
 
-type blobParams struct {
-	Size   int64
-	Number int
-	Hex    bool
-}
-
-func (p *blobParams) retriveParams(ctx *cli.Context) error {
-	p.Size = ctx.Int64(sizeParam)
-	p.Number = ctx.Int(numberParam)
-	p.Hex = ctx.Bool(hexParam)
-
-	return p.validate()
-}
-
-func (p *blobParams) validate() error {
-	const (
-		errSizeDivisibleBy8 = entities.Error("`size` parameter must be divisible by 8")
-		errSizeExceeded     = entities.Error("size exceed")
-	)
-
-	if err := validation.Validate(
-		p.Size,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(sizeMax),
-	); err != nil {
-		return fmt.Errorf("`size` param is invalid: %w", err)
-	}
+type Params = client.BlobParams
 
-	if err := validation.Validate(
-		p.Number,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(numberMax),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
-	}
-
-	if p.Size%8 != 0 {
-		return errSizeDivisibleBy8
+// nolint: gomnd
+func DefaultParams() Params {
+	return Params{
+		Size:   64,
+		Number: 1,
 	}
+}
 
-	if totalSize := p.Size * int64(p.Number); totalSize > sizeMax {
-		return fmt.Errorf("%w: %d > %d", errSizeExceeded, totalSize, sizeMax)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		Size:   ctx.Int64(sizeParam),
+		Number: ctx.Int(numberParam),
+		Hex:    ctx.Bool(hexParam),
 	}
 
-	return nil
+	return p, p.Validate()
 }
 
-func blob(cfg *config.AppConfig) cli.ActionFunc { // nolint: funlen
+func blob(cfg *config.AppConfig) cli.ActionFunc {
 	return func(cCtx *cli.Context) error {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params blobParams
-
-		if err := params.retriveParams(cCtx); err != nil {
-			return err
-		}
-
-		blobReq := blobRequest{
-			APIKey:     cfg.APIKey,
-			Size:       params.Size,
-			Number:     params.Number,
-			Format:     blobFormat(params.Hex),
-			PregenRand: cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, blobReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    blobResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-			decoder = getDecoder(params.Hex)
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData := make([]interface{}, 0, len(data))
-
-		for _, v := range data {
-			value, err := decoder(v)
-			if err != nil {
-				return fmt.Errorf("decode random data: %w", err)
-			}
-
-			outputData = append(outputData, string(value))
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -170,30 +92,17 @@ func blob(cfg *config.AppConfig) cli.ActionFunc { // nolint: funlen
 	}
 }
 
-type blobRequest struct {
-	APIKey     string              `json:"apiKey"`
-	Size       int64               `json:"size"`
-	Number     int                 `json:"n"`
-	Format     string              `json:"format"`
-	PregenRand entities.PregenRand `json:"pregeneratedRandomization"`
-}
-
-type blobResponseData []string
-
-func blobFormat(isHex bool) string {
-	if isHex {
-		return hexFormat
+// Generate retrieves blobs for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Blobs(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
 	}
 
-	return base64Format
-}
-
-type blobDecoder func(string) ([]byte, error)
-
-func getDecoder(isHex bool) blobDecoder {
-	if isHex {
-		return hex.DecodeString
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, string(v))
 	}
 
-	return base64.StdEncoding.DecodeString
+	return outputData, apiInfo, nil
 }
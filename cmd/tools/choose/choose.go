@@ -0,0 +1,177 @@
+package choose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/choose"
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	CommandName  = "choose"
+	pickParam    = "pick"
+	replaceParam = "replace"
+	inputParam   = "input"
+	explainParam = "explain"
+)
+
+const errInputAndArgs = entities.Error("items are read from args OR input file. Not both")
+
+func NewChooseCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "choose items proportionally to weights, e.g. alice:3 bob:1",
+		ArgsUsage: "[NAME[:WEIGHT]...]",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    pickParam,
+				Usage:   "number of winners",
+				Aliases: []string{"k"},
+				Value:   1,
+			},
+			&cli.BoolFlag{
+				Name:    replaceParam,
+				Usage:   "item may win more than once",
+				Aliases: []string{"r"},
+			},
+			&cli.StringFlag{
+				Name:    inputParam,
+				Usage:   "CSV file with NAME,WEIGHT rows, - for stdin",
+				Aliases: []string{"i"},
+			},
+			&cli.BoolFlag{
+				Name:  explainParam,
+				Usage: "print cumulative weights and random values used by the draw",
+			},
+		},
+		Action: chooseAction(cfg),
+	}
+}
+
+type Params struct {
+	Items   []choose.Item
+	Pick    int
+	Replace bool
+}
+
+func retrieveParams(cCtx *cli.Context) (Params, error) {
+	p := Params{
+		Pick:    cCtx.Int(pickParam),
+		Replace: cCtx.Bool(replaceParam),
+	}
+
+	input := cCtx.String(inputParam)
+
+	switch {
+	case input != "" && cCtx.NArg() > 0:
+		return p, errInputAndArgs
+	case cCtx.NArg() > 0:
+		for _, arg := range cCtx.Args().Slice() {
+			item, err := choose.ParseItem(arg)
+			if err != nil {
+				return p, err // nolint: wrapcheck
+			}
+
+			p.Items = append(p.Items, item)
+		}
+	case input == "" || input == "-":
+		items, err := readItems(cCtx.App.Reader)
+		if err != nil {
+			return p, err
+		}
+
+		p.Items = items
+	default:
+		f, err := os.Open(input)
+		if err != nil {
+			return p, fmt.Errorf("open input: %w", err)
+		}
+
+		defer f.Close()
+
+		if p.Items, err = readItems(f); err != nil {
+			return p, err
+		}
+	}
+
+	return p, nil
+}
+
+func readItems(r io.Reader) ([]choose.Item, error) {
+	items, err := choose.ReadCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("read items: %w", err)
+	}
+
+	return items, nil
+}
+
+func chooseAction(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		params, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		res, apiInfo, err := Choose(ctx, cfg, params)
+		if err != nil {
+			return err
+		}
+
+		outputData := make([]interface{}, 0, len(res.Winners))
+		for _, i := range res.Winners {
+			outputData = append(outputData, res.Items[i].Name)
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		if cCtx.Bool(explainParam) {
+			return res.Explain(cfg.Output) // nolint: wrapcheck
+		}
+
+		return nil
+	}
+}
+
+// Choose draws winners with integers of [0, choose.RangeMax) from random.org.
+// Rejected values are replaced by another call. Returned APIInfo sums bits used by all calls.
+func Choose(ctx context.Context, cfg *config.AppConfig, params Params) (choose.Result, entities.APIInfo, error) {
+	var (
+		c       = cfg.Client()
+		apiInfo entities.APIInfo
+	)
+
+	ints := func(n int) ([]int, error) {
+		values, info, err := c.Integers(ctx, client.IntegerParams{
+			From:   0,
+			To:     choose.RangeMax - 1,
+			Number: n,
+		})
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		apiInfo = apiInfo.Merge(info)
+
+		return values, nil
+	}
+
+	res, err := choose.Draw(params.Items, params.Pick, params.Replace, ints)
+	if err != nil {
+		return choose.Result{}, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return res, apiInfo, nil
+}
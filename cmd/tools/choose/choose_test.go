@@ -0,0 +1,147 @@
+package choose_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	choosecmd "github.com/bohdanch-w/rand-api/cmd/tools/choose"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func newApp(cfg *config.AppConfig, stdin string) *cli.App {
+	return &cli.App{
+		Name:     "test",
+		Reader:   strings.NewReader(stdin),
+		Commands: []*cli.Command{choosecmd.NewChooseCommand(cfg)},
+	}
+}
+
+func TestChooseCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":1,"min":0,"max":999999999,
+					"replacement":true,"base":10,"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[123456789]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"bob"}, testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	var explanation bytes.Buffer
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+		Output:          &explanation,
+	}
+
+	err := newApp(appConfig, "").Run([]string{"main.go", "choose", "--explain", "alice:3", "bob:5"})
+	require.NoError(t, err)
+	require.Equal(t, `Weights (total 8):
+  1    alice            3          [0, 3)
+  2    bob              5          [3, 8)
+Values in [0, 1000000000), value v selects v mod total if v < limit, chosen items are removed:
+  123456789  limit 1000000000 123456789 mod 8 = 5 -> bob
+`, explanation.String())
+}
+
+func TestChooseCommand_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := filepath.Join(t.TempDir(), "items.csv")
+	require.NoError(t, os.WriteFile(input, []byte("name,weight\na,1\nb,2\nc,3\nd,4\n"), 0o600))
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	var winners []any
+
+	mockOutputProcessor.EXPECT().
+		GenerateRandOutput(gomock.Any(), gomock.Any()).
+		Do(func(data []any, _ entities.APIInfo) { winners = data }).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		Timeout:         time.Second * 5,
+		RandRetriever:   backend.NewSeeded("choose"),
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "").Run([]string{"main.go", "choose", "-k", "4", "-i", input})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []any{"a", "b", "c", "d"}, winners)
+}
+
+func TestChooseCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		stdin         string
+		expectedError string
+	}{
+		{
+			args:          []string{"choose"},
+			expectedError: "no items to choose from",
+		},
+		{
+			args:          []string{"choose", "-k", "3", "a:1", "b:2"},
+			expectedError: "invalid pick: 3 of 2 items",
+		},
+		{
+			args:          []string{"choose", "a:x"},
+			expectedError: `invalid item: "a" weight must be integer in [1, 1000000000]`,
+		},
+		{
+			args:          []string{"choose", "-i", "items.csv", "a"},
+			expectedError: "items are read from args OR input file. Not both",
+		},
+		{
+			args:          []string{"choose"},
+			stdin:         "a,\"1\n",
+			expectedError: `read items: read csv: parse error on line 1, column 6: extraneous or missing " in quoted-field`,
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{Timeout: time.Second * 5}
+
+		err := newApp(appConfig, tc.stdin).Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
@@ -2,13 +2,12 @@ package coin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -18,14 +17,11 @@ const (
 	formatParam     = "format"
 	numberParam     = "number"
 
-	method    = "generateIntegers"
 	numberMax = 10_000
 
 	formatEng = "eng"
 	formatUkr = "ukr"
 	formatNum = "num"
-
-	intBase = 10
 )
 
 const (
@@ -34,6 +30,8 @@ const (
 )
 
 func NewCoinCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:  coinCommandName,
 		Usage: "generate random coinflip result (two values possible)",
@@ -42,32 +40,39 @@ func NewCoinCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    numberParam,
 				Usage:   "number of values returned [-1000, 1000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 			&cli.StringFlag{
 				Name:    formatParam,
 				Usage:   "format printet result. One of 'eng' 'ukr' 'num'",
 				Aliases: []string{"f"},
-				Value:   "eng",
+				Value:   defaults.Format,
 			},
 		},
 		Action: coin(cfg),
 	}
 }
 
-type coinParams struct {
+type Params struct {
 	Format string
 	Number int
 }
 
-func (p *coinParams) retriveParams(ctx *cli.Context) error {
+func DefaultParams() Params {
+	return Params{
+		Format: formatEng,
+		Number: 1,
+	}
+}
+
+func (p *Params) retriveParams(ctx *cli.Context) error {
 	p.Format = ctx.String(formatParam)
 	p.Number = ctx.Int(numberParam)
 
-	return p.validate()
+	return p.Validate()
 }
 
-func (p *coinParams) validate() error {
+func (p *Params) Validate() error {
 	if err := validation.Validate(p.Format, validation.In(formatUkr, formatEng, formatNum)); err != nil {
 		return fmt.Errorf("`format` param is invalid: %w", err)
 	}
@@ -88,48 +93,13 @@ func coin(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params coinParams
+		var params Params
 
 		if err := params.retriveParams(cCtx); err != nil {
 			return err
 		}
 
-		coinReq := coinRequest{
-			APIKey:      cfg.APIKey,
-			Number:      params.Number,
-			Min:         0,
-			Max:         1,
-			Replacement: true,
-			Base:        intBase,
-			PregenRand:  cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, coinReq)
-		if err != nil {
-			return fmt.Errorf("create request: %w", err)
-		}
-
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
-		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    coinResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData, err := newFunction(params, data)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
 			return err
 		}
@@ -142,7 +112,26 @@ func coin(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-func newFunction(params coinParams, data coinResponseData) ([]interface{}, error) {
+// Generate retrieves coinflips for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Integers(ctx, client.IntegerParams{
+		From:   0,
+		To:     1,
+		Number: params.Number,
+	})
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	outputData, err := newFunction(params, data)
+	if err != nil {
+		return nil, entities.APIInfo{}, err
+	}
+
+	return outputData, apiInfo, nil
+}
+
+func newFunction(params Params, data []int) ([]interface{}, error) {
 	mapper, err := coinMappers(params.Format)
 	if err != nil {
 		return nil, err
@@ -185,15 +174,3 @@ func coinSide(v int, mapper coinMapper) (interface{}, error) {
 
 	return mapper[v], nil
 }
-
-type coinRequest struct {
-	APIKey      string              `json:"apiKey"`
-	Number      int                 `json:"n"`
-	Min         int64               `json:"min"`
-	Max         int64               `json:"max"`
-	Replacement bool                `json:"replacement"`
-	Base        int8                `json:"base"`
-	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
-}
-
-type coinResponseData []int
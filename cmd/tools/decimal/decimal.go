@@ -2,15 +2,11 @@ package decimal
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
-	"time"
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/shopspring/decimal"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -21,14 +17,11 @@ const (
 	placesParam = "places"
 	numberParam = "number"
 	uniqueParam = "unique"
-
-	method           = "generateDecimalFractions"
-	numberMax        = 10_000
-	decimalPlacesMax = 14
 )
 
-// nolint: gomnd
 func NewDecimalCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:    CommandName,
 		Usage:   "generate random decimal value in range [0, 1]",
@@ -38,19 +31,19 @@ func NewDecimalCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    baseParam,
 				Usage:   "returned value will be in range [0, base]",
 				Aliases: []string{"b"},
-				Value:   1,
+				Value:   defaults.Base,
 			},
 			&cli.IntFlag{
 				Name:    placesParam,
 				Usage:   "number of decimal places to use [1, 14]",
 				Aliases: []string{"p"},
-				Value:   6,
+				Value:   defaults.Places,
 			},
 			&cli.IntFlag{
 				Name:    numberParam,
 				Usage:   "number of values returned     [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 			&cli.BoolFlag{
 				Name:    uniqueParam,
@@ -62,50 +55,26 @@ func NewDecimalCommand(cfg *config.AppConfig) *cli.Command {
 	}
 }
 
-type decimalParams struct {
-	Base   float64
-	Places int
-	Number int
-	Unique bool
-}
-
-func (p *decimalParams) retrieveParams(ctx *cli.Context) error {
-	p.Base = ctx.Float64(baseParam)
-	p.Places = ctx.Int(placesParam)
-	p.Number = ctx.Int(numberParam)
-	p.Unique = ctx.Bool(uniqueParam)
+type Params = client.DecimalParams
 
-	return p.validate()
-}
-
-func (p *decimalParams) validate() error {
-	const errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
-
-	if err := validation.Validate(p.Base, validation.Required); err != nil {
-		return fmt.Errorf("`base` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(
-		p.Places, validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(decimalPlacesMax),
-	); err != nil {
-		return fmt.Errorf("`places` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(
-		p.Number, validation.Min(1),
-		validation.Max(numberMax),
-		validation.Required.Error("must be no less than 1"),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
+// nolint: gomnd
+func DefaultParams() Params {
+	return Params{
+		Base:   1,
+		Places: 6,
+		Number: 1,
 	}
+}
 
-	if p.Number > int(math.Pow10(p.Places)) {
-		return fmt.Errorf("%w decimal places = %d", errMaxUniqueRandomExceeded, p.Places)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		Base:   ctx.Float64(baseParam),
+		Places: ctx.Int(placesParam),
+		Number: ctx.Int(numberParam),
+		Unique: ctx.Bool(uniqueParam),
 	}
 
-	return nil
+	return p, p.Validate()
 }
 
 func randDecimal(cfg *config.AppConfig) cli.ActionFunc {
@@ -113,52 +82,14 @@ func randDecimal(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params decimalParams
-
-		if err := params.retrieveParams(cCtx); err != nil {
-			return err
-		}
-
-		decReq := decimalRequest{
-			APIKey:        cfg.APIKey,
-			Number:        params.Number,
-			DecimalPlaces: params.Places,
-			Replacement:   !params.Unique,
-			PregenRand:    cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, decReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get response: %w", err)
-		}
-
-		var (
-			data    decimalResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		base := decimal.NewFromFloat(params.Base)
-
-		outputData := make([]interface{}, 0, len(data))
-
-		for _, v := range data {
-			value, _ := base.Mul(decimal.NewFromFloat(v)).Float64()
-			outputData = append(outputData, value)
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -169,12 +100,17 @@ func randDecimal(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-type decimalRequest struct {
-	APIKey        string              `json:"apiKey"`
-	Number        int                 `json:"n"`
-	DecimalPlaces int                 `json:"decimalPlaces"`
-	Replacement   bool                `json:"replacement"`
-	PregenRand    entities.PregenRand `json:"pregeneratedRandomization"`
-}
+// Generate retrieves decimal fractions scaled to base for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Decimals(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, v)
+	}
 
-type decimalResponseData []float64
+	return outputData, apiInfo, nil
+}
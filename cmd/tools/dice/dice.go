@@ -0,0 +1,204 @@
+package dice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/dice"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	CommandName = "dice"
+	totalsParam = "totals"
+
+	// rangeMax is the largest range of integers requested at once.
+	rangeMax = 1_000_000_000
+)
+
+const errNoExpressions = entities.Error("at least one dice expression is required")
+
+func NewDiceCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "roll dice in RPG notation, e.g. 3d6+2 2d20kh1 4d6dl1 4dF 3d6!",
+		ArgsUsage: "EXPRESSION...",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    totalsParam,
+				Usage:   "print only totals of rolls",
+				Aliases: []string{"T"},
+			},
+		},
+		Action: roll(cfg),
+	}
+}
+
+func retrieveParams(cCtx *cli.Context) ([]dice.Expression, error) {
+	if cCtx.NArg() == 0 {
+		return nil, errNoExpressions
+	}
+
+	exprs := make([]dice.Expression, 0, cCtx.NArg())
+
+	for _, notation := range cCtx.Args().Slice() {
+		expr, err := dice.Parse(notation)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return exprs, nil
+}
+
+func roll(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		exprs, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		results, apiInfo, err := Roll(ctx, cfg, exprs)
+		if err != nil {
+			return err
+		}
+
+		outputData := make([]interface{}, 0, len(results))
+
+		for _, r := range results {
+			if cCtx.Bool(totalsParam) {
+				outputData = append(outputData, r.Total)
+			} else {
+				outputData = append(outputData, r.String())
+			}
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Roll rolls expressions. Dice of a round share one generateIntegers call
+// in [0, L), where L is least common multiple of their sides, and die with
+// S sides is value mod S + 1, which is uniform because S divides L. Returned
+// APIInfo sums bits used by all calls and is zero when 1-sided dice need none.
+func Roll(ctx context.Context, cfg *config.AppConfig, exprs []dice.Expression) ([]dice.Result, entities.APIInfo, error) {
+	var (
+		c       = cfg.Client()
+		apiInfo entities.APIInfo
+	)
+
+	draw := func(sides []int) ([]int, error) {
+		values := make([]int, len(sides))
+
+		for _, g := range groups(sides) {
+			if g.lcm == 1 {
+				for _, i := range g.dice {
+					values[i] = 1
+				}
+
+				continue
+			}
+
+			ints, info, err := c.Integers(ctx, client.IntegerParams{
+				From:   0,
+				To:     int64(g.lcm - 1),
+				Number: len(g.dice),
+			})
+			if err != nil {
+				return nil, err // nolint: wrapcheck
+			}
+
+			for k, i := range g.dice {
+				values[i] = ints[k]%sides[i] + 1
+			}
+
+			apiInfo = apiInfo.Merge(info)
+		}
+
+		return values, nil
+	}
+
+	results, err := dice.Roll(exprs, draw)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return results, apiInfo, nil
+}
+
+type group struct {
+	lcm  int
+	dice []int
+}
+
+// groups splits dice into as few groups as possible with lcm of sides
+// within requested range, usually one.
+func groups(sides []int) []group {
+	distinct := make([]int, 0, len(sides))
+	seen := make(map[int]bool)
+
+	for _, s := range sides {
+		if !seen[s] {
+			seen[s] = true
+			distinct = append(distinct, s)
+		}
+	}
+
+	sort.Ints(distinct)
+
+	var (
+		res    []group
+		bySide = make(map[int]int)
+	)
+
+	for _, s := range distinct {
+		idx := -1
+
+		for i, g := range res {
+			if l := lcm(g.lcm, s); g.lcm != 1 && s != 1 && l <= rangeMax {
+				res[i].lcm, idx = l, i
+
+				break
+			}
+		}
+
+		if idx < 0 {
+			res = append(res, group{lcm: s})
+			idx = len(res) - 1
+		}
+
+		bySide[s] = idx
+	}
+
+	for i, s := range sides {
+		res[bySide[s]].dice = append(res[bySide[s]].dice, i)
+	}
+
+	return res
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
@@ -0,0 +1,164 @@
+package dice_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	dicecmd "github.com/bohdanch-w/rand-api/cmd/tools/dice"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/dice"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/output"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/quota"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestDiceCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				// d6 and d20 share range of 60 values
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":5,"min":0,"max":59,
+					"replacement":true,"base":10,"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[3, 59, 12, 2, 36]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput(
+				[]any{"3d6+2=[4,6,1]+2=13", "2d20kh1=[(3),17]=17"},
+				testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{dicecmd.NewDiceCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "dice", "3d6+2", "2d20kh1"})
+	require.NoError(t, err)
+}
+
+func TestDiceCommand_OneSided(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var out, logs bytes.Buffer
+
+	prev := log.Writer()
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(prev)
+
+	limits := quota.NewLimitsCache(filepath.Join(t.TempDir(), "limits.json"), time.Hour,
+		func(context.Context, string) (entities.UsageStatus, error) {
+			return entities.UsageStatus{TotalRequests: 1000, TotalBits: 250_000}, nil
+		})
+
+	outputProcessor := output.NewOutputProcessor(false, false, " ", &out)
+	outputProcessor.SetWarner(quota.NewWarner(quota.Config{}, "c6418ada-7874-4907-9367-f43c446686d3", limits, time.Second))
+
+	appConfig := &config.AppConfig{
+		Timeout: time.Second * 5,
+		// 1-sided dice need no request
+		RandRetriever:   mock.NewMockRandRetiever(ctrl),
+		OutputProcessor: outputProcessor,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{dicecmd.NewDiceCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "dice", "-T", "2d1"})
+	require.NoError(t, err)
+	require.Equal(t, "2", strings.TrimSpace(out.String()))
+	require.Empty(t, logs.String(), "no quota warning without request")
+}
+
+func TestRoll_Groups(t *testing.T) {
+	// least common multiple of large prime dice exceeds range of one request
+	exprs := make([]dice.Expression, 0, 3)
+
+	for _, notation := range []string{"2d999983", "1d999979", "1d1+3d6!"} {
+		expr, err := dice.Parse(notation)
+		require.NoError(t, err)
+
+		exprs = append(exprs, expr)
+	}
+
+	read := func() []dice.Result {
+		cfg := &config.AppConfig{RandRetriever: backend.NewSeeded("dice")}
+
+		results, apiInfo, err := dicecmd.Roll(t.Context(), cfg, exprs)
+		require.NoError(t, err)
+		require.NotZero(t, apiInfo.BitsUsed)
+
+		return results
+	}
+
+	results := read()
+	require.Len(t, results, 3)
+	require.Len(t, results[0].Terms[0].Rolls, 2)
+	require.Len(t, results[1].Terms[0].Rolls, 1)
+	require.Equal(t, 1, results[2].Terms[0].Rolls[0].Value)
+
+	for _, r := range results[0].Terms[0].Rolls {
+		require.True(t, r.Value >= 1 && r.Value <= 999983, r.Value)
+	}
+
+	require.Equal(t, results, read(), "seeded rolls are repeatable")
+}
+
+func TestDiceCommand_BadParams(t *testing.T) {
+	appConfig := &config.AppConfig{Timeout: time.Second * 5}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{dicecmd.NewDiceCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "dice"})
+	require.EqualError(t, err, "at least one dice expression is required")
+
+	err = app.Run([]string{"main.go", "dice", "3d6", "2d20kh3"})
+	require.ErrorIs(t, err, dice.ErrInvalidNotation)
+}
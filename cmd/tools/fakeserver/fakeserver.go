@@ -0,0 +1,119 @@
+package fakeserver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	mrand "math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/serve"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/randapi/fakeserver"
+)
+
+const (
+	CommandName        = "fake-server"
+	addrParam          = "addr"
+	keyParam           = "key"
+	advisoryDelayParam = "advisory-delay"
+	seedParam          = "seed"
+
+	defaultAddr       = "127.0.0.1:8090"
+	readHeaderTimeout = 5 * time.Second
+)
+
+const errInvalidKeyFormat = entities.Error("expected KEY[:BITS[:REQUESTS]]")
+
+func NewFakeServerCommand(_ *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "serve fake random.org JSON-RPC API for offline integration tests",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    addrParam,
+				Usage:   "address to listen on",
+				Aliases: []string{"a"},
+				Value:   defaultAddr,
+			},
+			&cli.StringSliceFlag{
+				Name: keyParam,
+				Usage: fmt.Sprintf("accepted api key as KEY[:BITS[:REQUESTS]]. May be repeated. "+
+					"Any key is accepted with %d bits and %d requests if unset",
+					fakeserver.DefaultBits, fakeserver.DefaultRequests),
+			},
+			&cli.DurationFlag{
+				Name:  advisoryDelayParam,
+				Usage: "advisory delay reported in responses",
+			},
+			&cli.StringFlag{
+				Name:  seedParam,
+				Usage: "seed making generated values and ticket ids reproducible",
+			},
+		},
+		Action: runFakeServer,
+	}
+}
+
+func runFakeServer(cCtx *cli.Context) error {
+	opts := fakeserver.Options{AdvisoryDelay: cCtx.Duration(advisoryDelayParam)}
+
+	for _, s := range cCtx.StringSlice(keyParam) {
+		key, err := parseKey(s)
+		if err != nil {
+			return fmt.Errorf("`%s` param is invalid: %w", keyParam, err)
+		}
+
+		opts.Keys = append(opts.Keys, key)
+	}
+
+	if cCtx.IsSet(seedParam) {
+		opts.Rand = mrand.NewChaCha8(sha256.Sum256([]byte(cCtx.String(seedParam))))
+	}
+
+	fs, err := fakeserver.New(opts)
+	if err != nil {
+		return fmt.Errorf("create fake server: %w", err)
+	}
+
+	srv := &http.Server{
+		Addr:              cCtx.String(addrParam),
+		Handler:           fs,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return serve.ListenAndServe(cCtx.Context, srv)
+}
+
+func parseKey(s string) (fakeserver.Key, error) {
+	parts := strings.Split(s, ":")
+	if parts[0] == "" || len(parts) > 3 {
+		return fakeserver.Key{}, fmt.Errorf("%w: %q", errInvalidKeyFormat, s)
+	}
+
+	key := fakeserver.Key{
+		APIKey:       parts[0],
+		BitsLeft:     fakeserver.DefaultBits,
+		RequestsLeft: fakeserver.DefaultRequests,
+	}
+
+	for i, limit := range []*int64{&key.BitsLeft, &key.RequestsLeft} {
+		if len(parts) <= i+1 {
+			break
+		}
+
+		v, err := strconv.ParseInt(parts[i+1], 10, 64)
+		if err != nil || v < 0 {
+			return fakeserver.Key{}, fmt.Errorf("%w: %q", errInvalidKeyFormat, s)
+		}
+
+		*limit = v
+	}
+
+	return key, nil
+}
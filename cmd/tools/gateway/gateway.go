@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/serve"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/gateway"
+	"github.com/bohdanch-w/rand-api/tracing"
+)
+
+const (
+	CommandName = "gateway"
+	addrParam   = "addr"
+
+	defaultAddr       = "127.0.0.1:8081"
+	readHeaderTimeout = 5 * time.Second
+)
+
+const (
+	errNoClients           = entities.Error("no gateway clients configured")
+	errBudgetsNeedLedger   = entities.Error("gateway budgets require usage ledger to be enabled")
+	errForwarderNotPresent = entities.Error("request forwarding is not available")
+)
+
+func NewGatewayCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "accept random.org JSON-RPC requests with client tokens in place of apiKey and forward them",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    addrParam,
+				Usage:   "address to listen on",
+				Aliases: []string{"a"},
+				Value:   defaultAddr,
+			},
+		},
+		Action: runGateway(cfg),
+	}
+}
+
+func runGateway(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		gwCfg := cfg.File.Gateway
+
+		if len(gwCfg.Clients) == 0 {
+			return errNoClients
+		}
+
+		if gwCfg.HasBudgets() && cfg.Ledger == nil {
+			return errBudgetsNeedLedger
+		}
+
+		if cfg.Forwarder == nil {
+			return errForwarderNotPresent
+		}
+
+		gw := gateway.New(gwCfg, cfg.Forwarder, cfg.APIKey, cfg.Ledger, cfg.Timeout)
+
+		srv := &http.Server{
+			Addr:              cCtx.String(addrParam),
+			Handler:           cfg.Metrics.Expose(tracing.Handler(gw.Handler(), CommandName)),
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+
+		return serve.ListenAndServe(cCtx.Context, srv)
+	}
+}
@@ -2,13 +2,11 @@ package gausian
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -19,16 +17,11 @@ const (
 	deviationParam  = "deviation"
 	signDigitsParam = "signdig"
 	numberParam     = "number"
-
-	method               = "generateGaussians"
-	rangeMaxMin          = 1_000_000
-	minSignificantDigits = 2
-	maxSignificantDigits = 14
-	numberMax            = 10_000
 )
 
-// nolint: gomnd
 func NewGausianCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:    CommandName,
 		Aliases: []string{"gaus"},
@@ -38,82 +31,52 @@ func NewGausianCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    meanParam,
 				Usage:   "mean value of distribution         [-1000000, 1000000]",
 				Aliases: []string{"m"},
-				Value:   0,
+				Value:   defaults.Mean,
 			},
 			&cli.Float64Flag{
 				Name:    deviationParam,
 				Usage:   "standart deviation of distribution [-1000000, 1000000]",
 				Aliases: []string{"d"},
-				Value:   1,
+				Value:   defaults.Deviation,
 			},
 			&cli.IntFlag{
 				Name:    signDigitsParam,
 				Usage:   "number of significant digits [2, 14]",
 				Aliases: []string{"s"},
-				Value:   6,
+				Value:   defaults.SignificantDigits,
 			},
 			&cli.IntFlag{
 				Name:    numberParam,
 				Usage:   "number of values returned    [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 		},
 		Action: gausian(cfg),
 	}
 }
 
-type gausianParams struct {
-	Mean              float64
-	Deviation         float64
-	SignificantDigits int
-	Number            int
-}
-
-func (p *gausianParams) retriveParams(ctx *cli.Context) error {
-	p.Mean = ctx.Float64(meanParam)
-	p.Deviation = ctx.Float64(deviationParam)
-	p.SignificantDigits = ctx.Int(signDigitsParam)
-	p.Number = ctx.Int(numberParam)
-
-	return p.validate()
-}
+type Params = client.GaussianParams
 
-func (p *gausianParams) validate() error {
-	if err := validation.Validate(
-		p.Mean,
-		validation.Min(float64(-rangeMaxMin)),
-		validation.Max(float64(rangeMaxMin)),
-	); err != nil {
-		return fmt.Errorf("`mean` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(
-		p.Deviation,
-		validation.Min(float64(-rangeMaxMin)),
-		validation.Max(float64(rangeMaxMin)),
-	); err != nil {
-		return fmt.Errorf("`deviation` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(
-		p.SignificantDigits,
-		validation.Required.Error("must be no less than 2"),
-		validation.Min(minSignificantDigits),
-		validation.Max(maxSignificantDigits),
-	); err != nil {
-		return fmt.Errorf("`signdig` param is invalid: %w", err)
+// nolint: gomnd
+func DefaultParams() Params {
+	return Params{
+		Mean:              0,
+		Deviation:         1,
+		SignificantDigits: 6,
+		Number:            1,
 	}
+}
 
-	if err := validation.Validate(
-		p.Number, validation.Min(1),
-		validation.Max(numberMax),
-		validation.Required.Error("must be no less than 1"),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		Mean:              ctx.Float64(meanParam),
+		Deviation:         ctx.Float64(deviationParam),
+		SignificantDigits: ctx.Int(signDigitsParam),
+		Number:            ctx.Int(numberParam),
 	}
 
-	return nil
+	return p, p.Validate()
 }
 
 func gausian(cfg *config.AppConfig) cli.ActionFunc {
@@ -121,49 +84,14 @@ func gausian(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params gausianParams
-
-		if err := params.retriveParams(cCtx); err != nil {
-			return err
-		}
-
-		gausReq := gausianRequest{
-			APIKey:            cfg.APIKey,
-			Mean:              params.Mean,
-			StandardDeviation: params.Deviation,
-			SignificantDigits: params.SignificantDigits,
-			Number:            params.Number,
-			PregenRand:        cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, gausReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    gausianResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData := make([]interface{}, 0, len(data))
-		for _, v := range data {
-			outputData = append(outputData, v)
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -174,13 +102,17 @@ func gausian(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-type gausianRequest struct {
-	APIKey            string              `json:"apiKey"`
-	Mean              float64             `json:"mean"`
-	StandardDeviation float64             `json:"standardDeviation"`
-	SignificantDigits int                 `json:"significantDigits"`
-	Number            int                 `json:"n"`
-	PregenRand        entities.PregenRand `json:"pregeneratedRandomization"`
-}
+// Generate retrieves gaussian values for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Gaussians(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
 
-type gausianResponseData []float64
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, v)
+	}
+
+	return outputData, apiInfo, nil
+}
@@ -0,0 +1,185 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/groups"
+)
+
+const (
+	CommandName   = "groups"
+	sizeParam     = "size"
+	countParam    = "count"
+	stratifyParam = "stratify"
+	pinParam      = "pin"
+	formatParam   = "format"
+
+	stdinFile = "-"
+)
+
+const (
+	errMembersFile = entities.Error("exactly one members file is required")
+	errSizeOrCount = entities.Error("groups are set by --size OR --count")
+)
+
+func NewGroupsCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "split members into balanced random groups by --size or --count",
+		ArgsUsage: "MEMBERS.csv",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  sizeParam,
+				Usage: "largest group size, number of groups is derived",
+			},
+			&cli.IntFlag{
+				Name:  countParam,
+				Usage: "number of groups",
+			},
+			&cli.StringFlag{
+				Name:  stratifyParam,
+				Usage: "column spread across groups: 1-based number or name in header row",
+			},
+			&cli.StringSliceFlag{
+				Name:  pinParam,
+				Usage: "NAME:GROUP puts member to 1-based group, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:  formatParam,
+				Usage: "output format: text, json or csv",
+				Value: groups.FormatText,
+			},
+		},
+		Action: split(cfg),
+	}
+}
+
+type Params struct {
+	Plan   *groups.Plan
+	Format string
+}
+
+func retrieveParams(cCtx *cli.Context) (Params, error) {
+	if cCtx.NArg() != 1 {
+		return Params{}, errMembersFile
+	}
+
+	size, count := cCtx.Int(sizeParam), cCtx.Int(countParam)
+	if (size == 0) == (count == 0) {
+		return Params{}, errSizeOrCount
+	}
+
+	var (
+		file = cCtx.Args().First()
+		r    io.Reader
+	)
+
+	if file == stdinFile {
+		r = cCtx.App.Reader
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return Params{}, fmt.Errorf("open members: %w", err)
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	members, err := groups.ReadMembers(r, cCtx.String(stratifyParam))
+	if err != nil {
+		return Params{}, fmt.Errorf("read members: %w", err)
+	}
+
+	if size != 0 {
+		count = groups.CountForSize(len(members), size)
+	}
+
+	pins := make([]groups.Pin, 0, len(cCtx.StringSlice(pinParam)))
+
+	for _, s := range cCtx.StringSlice(pinParam) {
+		pin, err := groups.ParsePin(s)
+		if err != nil {
+			return Params{}, err // nolint: wrapcheck
+		}
+
+		pins = append(pins, pin)
+	}
+
+	plan, err := groups.NewPlan(members, count, pins)
+	if err != nil {
+		return Params{}, err // nolint: wrapcheck
+	}
+
+	return Params{Plan: plan, Format: cCtx.String(formatParam)}, nil
+}
+
+func split(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		params, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		// fail on unknown format before spending quota
+		if _, err := groups.Format(nil, params.Format); err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		split, apiInfo, err := Split(ctx, cfg, params.Plan)
+		if err != nil {
+			return err
+		}
+
+		out, err := groups.Format(split, params.Format)
+		if err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput([]interface{}{out}, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Split draws all permutations of the plan with a single generateIntegerSequences call.
+func Split(ctx context.Context, cfg *config.AppConfig, plan *groups.Plan) ([][]groups.Member, entities.APIInfo, error) {
+	var apiInfo entities.APIInfo
+
+	perms := func(lengths []int) ([][]int, error) {
+		seqs := make([]client.SequenceParams, 0, len(lengths))
+		for _, l := range lengths {
+			seqs = append(seqs, client.SequenceParams{Length: l, From: 0, To: int64(l - 1), Unique: true})
+		}
+
+		values, info, err := cfg.Client().IntegerSequences(ctx, seqs)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		apiInfo = info
+
+		return values, nil
+	}
+
+	split, err := plan.Split(perms)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return split, apiInfo, nil
+}
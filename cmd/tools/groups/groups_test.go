@@ -0,0 +1,157 @@
+package groups_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	groupscmd "github.com/bohdanch-w/rand-api/cmd/tools/groups"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func newApp(cfg *config.AppConfig, stdin string) *cli.App {
+	return &cli.App{
+		Name:     "test",
+		Reader:   strings.NewReader(stdin),
+		Commands: []*cli.Command{groupscmd.NewGroupsCommand(cfg)},
+	}
+}
+
+func TestGroupsCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegerSequences",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegerSequences", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":3,"length":[2,2,3],
+					"min":[0,0,0],"max":[1,1,2],"replacement":[false,false,false],"base":10,
+					"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		// groups are dealt in order 2, 1: seniors ann, cid, then juniors fay, bob, dan
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[[1, 0], [0, 1], [2, 0, 1]]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"group,member\n1,cid\n1,bob\n2,ann\n2,fay\n2,dan"},
+				testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	members := filepath.Join(t.TempDir(), "members.csv")
+	require.NoError(t, os.WriteFile(members, []byte("name,level\nann,senior\nbob,junior\ncid,senior\ndan,junior\nfay,junior\n"), 0o600))
+
+	err := newApp(appConfig, "").
+		Run([]string{"main.go", "groups", "--size", "3", "--stratify", "level", "--format", "csv", members})
+	require.NoError(t, err)
+}
+
+func TestGroupsCommand_Pinned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var out string
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+	mockOutputProcessor.EXPECT().
+		GenerateRandOutput(gomock.Any(), gomock.Any()).
+		Do(func(data []any, _ entities.APIInfo) { out = data[0].(string) }).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		Timeout:         time.Second * 5,
+		RandRetriever:   backend.NewSeeded("groups"),
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "a\nb\nc\nd\ne\nf\ng\n").
+		Run([]string{"main.go", "groups", "--count", "2", "--pin", "a:2", "--pin", "b:2", "-"})
+	require.NoError(t, err)
+
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 2)
+	require.Regexp(t, `^Group 1: [c-g](, [c-g]){2,3}$`, lines[0])
+	require.Regexp(t, `^Group 2: a, b(, [c-g]){1,2}$`, lines[1])
+	require.Equal(t, 7, strings.Count(out, ",")+2)
+}
+
+func TestGroupsCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		stdin         string
+		expectedError string
+	}{
+		{
+			args:          []string{"groups", "--size", "2"},
+			expectedError: "exactly one members file is required",
+		},
+		{
+			args:          []string{"groups", "-"},
+			expectedError: "groups are set by --size OR --count",
+		},
+		{
+			args:          []string{"groups", "--size", "2", "--count", "2", "-"},
+			expectedError: "groups are set by --size OR --count",
+		},
+		{
+			args:          []string{"groups", "--count", "3", "-"},
+			stdin:         "a\nb\n",
+			expectedError: "invalid groups: 3 groups of 2 members",
+		},
+		{
+			args:          []string{"groups", "--count", "1", "--pin", "a", "-"},
+			stdin:         "a\nb\n",
+			expectedError: `invalid groups: pin "a" must be NAME:GROUP`,
+		},
+		{
+			args:          []string{"groups", "--count", "2", "--format", "xml", "-"},
+			stdin:         "a\nb\n",
+			expectedError: "invalid groups: format must be one of text, json, csv",
+		},
+		{
+			args:          []string{"groups", "--count", "2", "--stratify", "level", "-"},
+			stdin:         "name\na\nb\n",
+			expectedError: `read members: invalid groups: no "level" column in header`,
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{Timeout: time.Second * 5}
+
+		err := newApp(appConfig, tc.stdin).Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
@@ -2,13 +2,11 @@ package integer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -19,16 +17,11 @@ const (
 	toParam     = "to"
 	numberParam = "number"
 	uniqueParam = "unique"
-
-	method      = "generateIntegers"
-	rangeMaxMin = 1_000_000_000
-	numberMax   = 10_000
-
-	intBase = 10
 )
 
-// nolint: gomnd
 func NewIntegerCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:    commandName,
 		Aliases: []string{"int"},
@@ -38,19 +31,19 @@ func NewIntegerCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    fromParam,
 				Usage:   "bottom limit of random number [-1e9, 1e9]",
 				Aliases: []string{"f"},
-				Value:   1,
+				Value:   defaults.From,
 			},
 			&cli.Int64Flag{
 				Name:    toParam,
 				Usage:   "upper limit of random number  [-1e9, 1e9]",
 				Aliases: []string{"t"},
-				Value:   100,
+				Value:   defaults.To,
 			},
 			&cli.IntFlag{
 				Name:    numberParam,
 				Usage:   "number of values returned     [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 			&cli.BoolFlag{
 				Name:    uniqueParam,
@@ -62,54 +55,26 @@ func NewIntegerCommand(cfg *config.AppConfig) *cli.Command {
 	}
 }
 
-type integerParams struct {
-	From   int64
-	To     int64
-	Number int
-	Unique bool
-}
-
-func (p *integerParams) retriveParams(ctx *cli.Context) error {
-	p.From = ctx.Int64(fromParam)
-	p.To = ctx.Int64(toParam)
-	p.Number = ctx.Int(numberParam)
-	p.Unique = ctx.Bool(uniqueParam)
-
-	return p.validate()
-}
-
-func (p *integerParams) validate() error {
-	const (
-		errToBiggerThanFrom        = entities.Error("`from` param must be less than `to`")
-		errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
-	)
+type Params = client.IntegerParams
 
-	if err := validation.Validate(p.From, validation.Min(-rangeMaxMin), validation.Max(rangeMaxMin)); err != nil {
-		return fmt.Errorf("`from` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(p.To, validation.Min(-rangeMaxMin), validation.Max(rangeMaxMin)); err != nil {
-		return fmt.Errorf("`to` param is invalid: %w", err)
-	}
-
-	if err := validation.Validate(
-		p.Number,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(numberMax),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
-	}
-
-	if p.From >= p.To {
-		return fmt.Errorf("%w: from %d to %d", errToBiggerThanFrom, p.From, p.To)
+// nolint: gomnd
+func DefaultParams() Params {
+	return Params{
+		From:   1,
+		To:     100,
+		Number: 1,
 	}
+}
 
-	if (p.To-p.From) < int64(p.Number) && p.Unique {
-		return fmt.Errorf("%w in range %d - %d", errMaxUniqueRandomExceeded, p.From, p.To)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		From:   ctx.Int64(fromParam),
+		To:     ctx.Int64(toParam),
+		Number: ctx.Int(numberParam),
+		Unique: ctx.Bool(uniqueParam),
 	}
 
-	return nil
+	return p, p.Validate()
 }
 
 func integer(cfg *config.AppConfig) cli.ActionFunc {
@@ -117,50 +82,14 @@ func integer(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params integerParams
-
-		if err := params.retriveParams(cCtx); err != nil {
-			return err
-		}
-
-		intReq := integerRequest{
-			APIKey:      cfg.APIKey,
-			Number:      params.Number,
-			Min:         params.From,
-			Max:         params.To,
-			Replacement: !params.Unique,
-			Base:        intBase,
-			PregenRand:  cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, intReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    integerResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData := make([]interface{}, 0, len(data))
-		for _, v := range data {
-			outputData = append(outputData, v)
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -171,14 +100,17 @@ func integer(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-type integerRequest struct {
-	APIKey      string              `json:"apiKey"`
-	Number      int                 `json:"n"`
-	Min         int64               `json:"min"`
-	Max         int64               `json:"max"`
-	Replacement bool                `json:"replacement"`
-	Base        int8                `json:"base"`
-	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
-}
+// Generate retrieves integers for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Integers(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, v)
+	}
 
-type integerResponseData []int
+	return outputData, apiInfo, nil
+}
@@ -129,6 +129,58 @@ func TestIntegerCommand_SuccessWithParams(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIntegerCommand_UniqueWholeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encParams, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":6,"min":1,"max":6,
+					"replacement":false,"base":10,"pregeneratedRandomization":null}`, string(encParams))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, "[3, 1, 6, 2, 5, 4]"), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{3, 1, 6, 2, 5, 4}, testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{integer.NewIntegerCommand(appConfig)},
+	}
+
+	// range of 6 values holds exactly 6 unique ones
+	err := app.Run([]string{"main.go", "int", "-f", "1", "-t", "6", "-N", "6", "-u"})
+	require.NoError(t, err)
+
+	err = app.Run([]string{"main.go", "int", "-f", "1", "-t", "6", "-N", "7", "-u"})
+	require.EqualError(t, err, "`number` of unique requested values is greater than possible in range 1 - 6")
+}
+
 func TestIntegerCommand_BadParams(t *testing.T) {
 	appConfig := &config.AppConfig{
 		APIKey:  "c6418ada-7874-4907-9367-f43c446686d3",
@@ -0,0 +1,154 @@
+package lottery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/lottery"
+)
+
+const (
+	CommandName = "lottery"
+	poolParam   = "pool"
+	presetParam = "preset"
+	sortedParam = "sorted"
+
+	numberSep = ","
+)
+
+const (
+	errPoolAndPreset = entities.Error("pools are set by --pool OR --preset. Not both")
+	errNoPools       = entities.Error("at least one --pool or --preset is required")
+	errUnknownPreset = entities.Error("unknown lottery preset")
+)
+
+func NewLotteryCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "draw distinct numbers from every pool in a single request, e.g. --pool 5/50 --pool 2/12",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  poolParam,
+				Usage: "pool K/N draws K distinct numbers of 1..N, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:    presetParam,
+				Usage:   "pools of lottery from `lotteries` of configuration file",
+				Aliases: []string{"p"},
+			},
+			&cli.BoolFlag{
+				Name:    sortedParam,
+				Usage:   "sort numbers of every pool in ascending order",
+				Aliases: []string{"S"},
+			},
+		},
+		Action: draw(cfg),
+	}
+}
+
+func retrieveParams(cCtx *cli.Context, presets lottery.Presets) ([]lottery.Pool, error) {
+	var (
+		notations = cCtx.StringSlice(poolParam)
+		preset    = cCtx.String(presetParam)
+	)
+
+	switch {
+	case len(notations) > 0 && preset != "":
+		return nil, errPoolAndPreset
+	case preset != "":
+		pools, ok := presets[preset]
+		if !ok {
+			return nil, fmt.Errorf("%w %q, known: %s", errUnknownPreset, preset, strings.Join(presets.Names(), ", "))
+		}
+
+		return pools, nil
+	case len(notations) == 0:
+		return nil, errNoPools
+	}
+
+	pools := make([]lottery.Pool, 0, len(notations))
+
+	for _, s := range notations {
+		p, err := lottery.ParsePool(s)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		pools = append(pools, p)
+	}
+
+	return pools, lottery.ValidatePools(pools) // nolint: wrapcheck
+}
+
+func draw(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		pools, err := retrieveParams(cCtx, cfg.File.Lotteries)
+		if err != nil {
+			return err
+		}
+
+		draws, apiInfo, err := Draw(ctx, cfg, pools)
+		if err != nil {
+			return err
+		}
+
+		outputData := make([]interface{}, 0, len(draws))
+
+		for _, numbers := range draws {
+			if cCtx.Bool(sortedParam) {
+				sort.Ints(numbers)
+			}
+
+			outputData = append(outputData, join(numbers))
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Draw draws numbers of all pools with one generateIntegerSequences call,
+// a sequence without replacement per pool.
+func Draw(ctx context.Context, cfg *config.AppConfig, pools []lottery.Pool) ([][]int, entities.APIInfo, error) {
+	seqs := make([]client.SequenceParams, 0, len(pools))
+
+	for _, p := range pools {
+		seqs = append(seqs, client.SequenceParams{
+			Length: p.Pick,
+			From:   1,
+			To:     int64(p.Max),
+			Unique: true,
+		})
+	}
+
+	draws, apiInfo, err := cfg.Client().IntegerSequences(ctx, seqs)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return draws, apiInfo, nil
+}
+
+func join(numbers []int) string {
+	s := make([]string, 0, len(numbers))
+
+	for _, n := range numbers {
+		s = append(s, strconv.Itoa(n))
+	}
+
+	return strings.Join(s, numberSep)
+}
@@ -0,0 +1,136 @@
+package lottery_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	lotterycmd "github.com/bohdanch-w/rand-api/cmd/tools/lottery"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/lottery"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestLotteryCommand(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected []any
+	}{
+		{
+			args:     []string{"lottery", "--pool", "5/50", "--pool", "2/12"},
+			expected: []any{"41,3,17,48,22", "9,4"},
+		},
+		{
+			args:     []string{"lottery", "--preset", "euromillions", "--sorted"},
+			expected: []any{"3,17,22,41,48", "4,9"},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctrl := gomock.NewController(t)
+
+		req := entities.RandomRequest{
+			ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+			Method: "generateIntegerSequences",
+		}
+
+		mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+		mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+		gomock.InOrder(
+			mockRandRetriever.EXPECT().
+				NewRequest("generateIntegerSequences", gomock.Any()).
+				Do(func(_ string, params any) {
+					encReq, err := json.Marshal(params)
+					require.NoError(t, err)
+					require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":2,"length":[5,2],
+						"min":[1,1],"max":[50,12],"replacement":[false,false],"base":10,
+						"pregeneratedRandomization":null}`, string(encReq))
+				}).
+				Return(req, nil),
+
+			mockRandRetriever.EXPECT().
+				ExecuteRequest(gomock.Any(), &req).
+				Return(testutils.TestRandResult(t, `[[41, 3, 17, 48, 22], [9, 4]]`), nil),
+
+			mockOutputProcessor.EXPECT().
+				GenerateRandOutput(tc.expected, testutils.TestRandAPIInfo(t, req.ID)).
+				Return(nil),
+		)
+
+		appConfig := &config.AppConfig{
+			APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+			Timeout:         time.Second * 5,
+			RandRetriever:   mockRandRetriever,
+			OutputProcessor: mockOutputProcessor,
+			File: config.FileConfig{
+				Lotteries: lottery.Presets{"euromillions": {{Pick: 5, Max: 50}, {Pick: 2, Max: 12}}},
+			},
+		}
+
+		app := &cli.App{
+			Name:     "test",
+			Commands: []*cli.Command{lotterycmd.NewLotteryCommand(appConfig)},
+		}
+
+		err := app.Run(append([]string{"main.go"}, tc.args...))
+		require.NoError(t, err)
+
+		ctrl.Finish()
+	}
+}
+
+func TestLotteryCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"lottery"},
+			expectedError: "at least one --pool or --preset is required",
+		},
+		{
+			args:          []string{"lottery", "--pool", "5/50", "--preset", "euromillions"},
+			expectedError: "pools are set by --pool OR --preset. Not both",
+		},
+		{
+			args:          []string{"lottery", "-p", "powerball"},
+			expectedError: `unknown lottery preset "powerball", known: euromillions, lotto`,
+		},
+		{
+			args:          []string{"lottery", "--pool", "7/6"},
+			expectedError: "invalid pool: 7/6, K must be in [1, N]",
+		},
+		{
+			args:          []string{"lottery", "--pool", "5-50"},
+			expectedError: `invalid pool: "5-50" must be K/N`,
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{
+			Timeout: time.Second * 5,
+			File: config.FileConfig{
+				Lotteries: lottery.Presets{
+					"euromillions": {{Pick: 5, Max: 50}, {Pick: 2, Max: 12}},
+					"lotto":        {{Pick: 6, Max: 49}},
+				},
+			},
+		}
+
+		app := &cli.App{
+			Name:     "test",
+			Commands: []*cli.Command{lotterycmd.NewLotteryCommand(appConfig)},
+		}
+
+		err := app.Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
@@ -0,0 +1,217 @@
+package pairs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pairs"
+)
+
+const (
+	CommandName  = "pairs"
+	inputParam   = "input"
+	excludeParam = "exclude"
+	outDirParam  = "out-dir"
+
+	outDirPerm  = 0o700
+	outFilePerm = 0o600
+	outFileExt  = ".txt"
+	pairSep     = "->"
+)
+
+const (
+	errInputAndArgs = entities.Error("participants are read from args OR input file. Not both")
+	errFileClash    = entities.Error("participants have the same output file")
+)
+
+func NewPairsCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "assign every participant another one to give a gift to (Secret Santa)",
+		ArgsUsage: "[NAME...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    inputParam,
+				Usage:   "CSV file with NAME[,EXCLUDED...] rows, excluded are names the participant must not draw, - for stdin",
+				Aliases: []string{"i"},
+			},
+			&cli.StringSliceFlag{
+				Name:  excludeParam,
+				Usage: "NAME:NAME couple who don't draw each other, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:    outDirParam,
+				Usage:   "write receiver of every participant to DIR/<participant>.txt instead of output",
+				Aliases: []string{"o"},
+			},
+		},
+		Action: assign(cfg),
+	}
+}
+
+func retrieveParams(cCtx *cli.Context) (*pairs.Problem, error) {
+	var (
+		participants []pairs.Participant
+		couples      []pairs.Couple
+		input        = cCtx.String(inputParam)
+		err          error
+	)
+
+	switch {
+	case input != "" && cCtx.NArg() > 0:
+		return nil, errInputAndArgs
+	case cCtx.NArg() > 0:
+		for _, name := range cCtx.Args().Slice() {
+			participants = append(participants, pairs.Participant{Name: name})
+		}
+	case input == "" || input == "-":
+		if participants, err = pairs.ReadCSV(cCtx.App.Reader); err != nil {
+			return nil, fmt.Errorf("read participants: %w", err)
+		}
+	default:
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, fmt.Errorf("open input: %w", err)
+		}
+
+		defer f.Close()
+
+		if participants, err = pairs.ReadCSV(f); err != nil {
+			return nil, fmt.Errorf("read participants: %w", err)
+		}
+	}
+
+	for _, s := range cCtx.StringSlice(excludeParam) {
+		c, err := pairs.ParseCouple(s)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		couples = append(couples, c)
+	}
+
+	return pairs.NewProblem(participants, couples) // nolint: wrapcheck
+}
+
+func assign(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		problem, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		assignment, apiInfo, err := Assign(ctx, cfg, problem)
+		if err != nil {
+			return err
+		}
+
+		var outputData []interface{}
+
+		if dir := cCtx.String(outDirParam); dir != "" {
+			if outputData, err = writeFiles(dir, problem.Names, assignment.Receivers); err != nil {
+				return err
+			}
+		} else {
+			for giver, receiver := range assignment.Receivers {
+				outputData = append(outputData, problem.Names[giver]+pairSep+problem.Names[receiver])
+			}
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Assign draws batches of permutations with generateIntegerSequences until
+// one satisfies the problem. Returned APIInfo sums bits used by all calls.
+func Assign(ctx context.Context, cfg *config.AppConfig, problem *pairs.Problem) (pairs.Assignment, entities.APIInfo, error) {
+	var (
+		c       = cfg.Client()
+		n       = len(problem.Names)
+		apiInfo entities.APIInfo
+	)
+
+	perms := func(batch int) ([][]int, error) {
+		seqs := make([]client.SequenceParams, batch)
+		for i := range seqs {
+			seqs[i] = client.SequenceParams{Length: n, From: 0, To: int64(n - 1), Unique: true}
+		}
+
+		values, info, err := c.IntegerSequences(ctx, seqs)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		apiInfo = apiInfo.Merge(info)
+
+		return values, nil
+	}
+
+	assignment, err := problem.Assign(perms)
+	if err != nil {
+		return pairs.Assignment{}, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return assignment, apiInfo, nil
+}
+
+// writeFiles writes name of the receiver to a file of every giver and returns paths of the files.
+func writeFiles(dir string, names []string, receivers []int) ([]interface{}, error) {
+	paths := make([]string, len(names))
+	seen := make(map[string]string, len(names))
+
+	for i, name := range names {
+		file := fileName(name)
+
+		if other, ok := seen[file]; ok {
+			return nil, fmt.Errorf("%w %s: %q and %q", errFileClash, file, other, name)
+		}
+
+		seen[file] = name
+		paths[i] = filepath.Join(dir, file)
+	}
+
+	if err := os.MkdirAll(dir, outDirPerm); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	written := make([]interface{}, 0, len(paths))
+
+	for giver, receiver := range receivers {
+		if err := os.WriteFile(paths[giver], []byte(names[receiver]+"\n"), outFilePerm); err != nil {
+			return nil, fmt.Errorf("write assignment: %w", err)
+		}
+
+		written = append(written, paths[giver])
+	}
+
+	return written, nil
+}
+
+// fileName keeps letters, digits, dots, dashes and underscores of name, other runes become underscores.
+func fileName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, name)
+
+	return strings.TrimLeft(safe, ".") + outFileExt
+}
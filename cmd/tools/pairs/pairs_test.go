@@ -0,0 +1,159 @@
+package pairs_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	pairscmd "github.com/bohdanch-w/rand-api/cmd/tools/pairs"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func newApp(cfg *config.AppConfig, stdin string) *cli.App {
+	return &cli.App{
+		Name:     "test",
+		Reader:   strings.NewReader(stdin),
+		Commands: []*cli.Command{pairscmd.NewPairsCommand(cfg)},
+	}
+}
+
+func repeat(s string, n int) string {
+	return "[" + strings.TrimSuffix(strings.Repeat(s+",", n), ",") + "]"
+}
+
+func TestPairsCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegerSequences",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegerSequences", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":10,
+					"length":`+repeat("4", 10)+`,"min":`+repeat("0", 10)+`,"max":`+repeat("3", 10)+`,
+					"replacement":`+repeat("false", 10)+`,"base":10,"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		// the first has fixed point, the second gives alice to bob, the third is valid
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[[0,2,3,1],[1,0,3,2],[2,3,1,0],
+				[1,2,3,0],[1,2,3,0],[1,2,3,0],[1,2,3,0],[1,2,3,0],[1,2,3,0],[1,2,3,0]]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"alice->carol", "bob->dave", "carol->bob", "dave->alice"},
+				testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "alice\nbob\ncarol\ndave\n").
+		Run([]string{"main.go", "pairs", "--exclude", "alice:bob"})
+	require.NoError(t, err)
+}
+
+func TestPairsCommand_OutDir(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := filepath.Join(t.TempDir(), "santa")
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+	mockOutputProcessor.EXPECT().
+		GenerateRandOutput([]any{
+			filepath.Join(dir, "Ann_Lee.txt"),
+			filepath.Join(dir, "bob.txt"),
+			filepath.Join(dir, "_.._carol.txt"),
+		}, gomock.Any()).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		Timeout:         time.Second * 5,
+		RandRetriever:   backend.NewSeeded("pairs"),
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "").Run([]string{"main.go", "pairs", "-o", dir, "Ann Lee", "bob", "../../carol"})
+	require.NoError(t, err)
+
+	receivers := make(map[string]bool)
+
+	for _, giver := range []string{"Ann_Lee", "bob", "_.._carol"} {
+		data, err := os.ReadFile(filepath.Join(dir, giver+".txt"))
+		require.NoError(t, err)
+
+		receiver := strings.TrimSuffix(string(data), "\n")
+		require.Contains(t, []string{"Ann Lee", "bob", "../../carol"}, receiver)
+		receivers[receiver] = true
+	}
+
+	require.Len(t, receivers, 3)
+}
+
+func TestPairsCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		stdin         string
+		expectedError string
+	}{
+		{
+			args:          []string{"pairs"},
+			expectedError: "invalid participant: 0 participants, must be in [2, 1000]",
+		},
+		{
+			args:          []string{"pairs", "-i", "people.csv", "alice"},
+			expectedError: "participants are read from args OR input file. Not both",
+		},
+		{
+			args:          []string{"pairs", "--exclude", "alice", "alice", "bob"},
+			expectedError: `invalid participant: exclusion "alice" must be NAME:NAME`,
+		},
+		{
+			args:          []string{"pairs", "--exclude", "alice:bob", "alice", "bob"},
+			expectedError: "no assignment satisfies exclusions",
+		},
+		{
+			args:          []string{"pairs", "-o", "out", "a b", "a_b"},
+			expectedError: `participants have the same output file a_b.txt: "a b" and "a_b"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{
+			Timeout:       time.Second * 5,
+			RandRetriever: backend.NewSeeded("pairs"),
+		}
+
+		err := newApp(appConfig, tc.stdin).Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
@@ -0,0 +1,121 @@
+package passphrase
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	passwordcmd "github.com/bohdanch-w/rand-api/cmd/tools/password"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/passgen"
+)
+
+const (
+	CommandName         = "passphrase"
+	wordsParam          = "words"
+	delimiterParam      = "delimiter"
+	capitalizationParam = "capitalize"
+	wordlistParam       = "wordlist"
+	numberParam         = "number"
+
+	defaultWords     = 6
+	defaultDelimiter = "-"
+)
+
+func NewPassphraseCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "generate diceware passphrases and report their entropy",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    wordsParam,
+				Usage:   fmt.Sprintf("number of words [1, %d]", passgen.WordsMax),
+				Aliases: []string{"w"},
+				Value:   defaultWords,
+			},
+			&cli.StringFlag{
+				Name:    delimiterParam,
+				Usage:   "separator of words",
+				Aliases: []string{"d"},
+				Value:   defaultDelimiter,
+			},
+			&cli.StringFlag{
+				Name:  capitalizationParam,
+				Usage: "capitalization of words: none, first, upper or random (first letter of every word with even odds)",
+				Value: string(passgen.CapNone),
+			},
+			&cli.StringFlag{
+				Name:        wordlistParam,
+				Usage:       "file with one word per line, optionally prefixed with dice rolls",
+				DefaultText: "embedded EFF large wordlist",
+			},
+			&cli.IntFlag{
+				Name:    numberParam,
+				Usage:   "number of passphrases, all words of them are at most 10000",
+				Aliases: []string{"N"},
+				Value:   1,
+			},
+		},
+		Action: generate(cfg),
+	}
+}
+
+func retrieveParams(cCtx *cli.Context) (passgen.Passphrase, error) {
+	p := passgen.Passphrase{
+		Words:          cCtx.Int(wordsParam),
+		Delimiter:      cCtx.String(delimiterParam),
+		Capitalization: passgen.Capitalization(cCtx.String(capitalizationParam)),
+	}
+
+	wordlist, err := readWordlist(cCtx.String(wordlistParam))
+	if err != nil {
+		return p, err
+	}
+
+	p.Wordlist = wordlist
+
+	return p, p.Validate() // nolint: wrapcheck
+}
+
+func readWordlist(path string) (passgen.Wordlist, error) {
+	if path == "" {
+		return passgen.DefaultWordlist() // nolint: wrapcheck
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open wordlist: %w", err)
+	}
+
+	defer f.Close()
+
+	return passgen.ParseWordlist(f) // nolint: wrapcheck
+}
+
+func generate(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		params, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		var apiInfo entities.APIInfo
+
+		phrases, err := params.Generate(cCtx.Int(numberParam), passwordcmd.Sequences(ctx, cfg, &apiInfo))
+		if err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(passwordcmd.Format(phrases), apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
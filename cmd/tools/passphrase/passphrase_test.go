@@ -0,0 +1,108 @@
+package passphrase_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	passphrasecmd "github.com/bohdanch-w/rand-api/cmd/tools/passphrase"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestPassphraseCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wordlist := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(wordlist, []byte("11\tapple\n12\tbanana\n13\tcherry\n14\tdate\n"), 0o600))
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegerSequences",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegerSequences", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":1,"length":[6],
+					"min":[0],"max":[3],"replacement":[true],"base":10,
+					"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[[3, 0, 2, 1, 1, 0]]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"Date Apple Cherry (6.0 bits)", "Banana Banana Apple (6.0 bits)"},
+				testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{passphrasecmd.NewPassphraseCommand(appConfig)},
+	}
+
+	err := app.Run([]string{
+		"main.go", "passphrase", "--wordlist", wordlist, "--words", "3", "-d", " ", "--capitalize", "first", "-N", "2",
+	})
+	require.NoError(t, err)
+}
+
+func TestPassphraseCommand_BadParams(t *testing.T) {
+	wordlist := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(wordlist, []byte("apple\nbanana\n"), 0o600))
+
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"passphrase", "--wordlist", wordlist, "--words", "0"},
+			expectedError: "invalid passphrase options: words must be in [1, 64]",
+		},
+		{
+			args:          []string{"passphrase", "--wordlist", wordlist, "--capitalize", "title"},
+			expectedError: `invalid passphrase options: unknown capitalization "title"`,
+		},
+		{
+			args:          []string{"passphrase", "--wordlist", wordlist, "--words", "10", "-N", "1001"},
+			expectedError: "too many values requested: number of words must be in [1, 10000]",
+		},
+	}
+
+	for _, tc := range testCases {
+		app := &cli.App{
+			Name:     "test",
+			Commands: []*cli.Command{passphrasecmd.NewPassphraseCommand(&config.AppConfig{Timeout: time.Second * 5})},
+		}
+
+		err := app.Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
@@ -0,0 +1,183 @@
+package password
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/passgen"
+)
+
+const (
+	CommandName      = "password"
+	lengthParam      = "length"
+	minLengthParam   = "min-length"
+	maxLengthParam   = "max-length"
+	minLowerParam    = "min-lower"
+	minUpperParam    = "min-upper"
+	minDigitsParam   = "min-digits"
+	minSymbolsParam  = "min-symbols"
+	symbolsParam     = "symbols"
+	noAmbiguousParam = "no-ambiguous"
+	numberParam      = "number"
+
+	defaultLength = 16
+)
+
+const errLengthAndRange = entities.Error("length is set by --length OR --min-length/--max-length. Not both")
+
+func NewPasswordCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "generate passwords satisfying policy without bias and report their entropy",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    lengthParam,
+				Usage:   fmt.Sprintf("length of passwords [1, %d]", passgen.LengthMax),
+				Aliases: []string{"l"},
+				Value:   defaultLength,
+			},
+			&cli.IntFlag{
+				Name:  minLengthParam,
+				Usage: "min length, length is uniform in [min-length, max-length]",
+			},
+			&cli.IntFlag{
+				Name:        maxLengthParam,
+				Usage:       "max length, length is uniform in [min-length, max-length]",
+				DefaultText: "min-length",
+			},
+			&cli.IntFlag{
+				Name:  minLowerParam,
+				Usage: "min number of lowercase letters",
+			},
+			&cli.IntFlag{
+				Name:  minUpperParam,
+				Usage: "min number of uppercase letters",
+			},
+			&cli.IntFlag{
+				Name:  minDigitsParam,
+				Usage: "min number of digits",
+			},
+			&cli.IntFlag{
+				Name:  minSymbolsParam,
+				Usage: "min number of symbols",
+			},
+			&cli.StringFlag{
+				Name:  symbolsParam,
+				Usage: "symbols used in passwords, empty for letters and digits only",
+				Value: passgen.Symbols,
+			},
+			&cli.BoolFlag{
+				Name:  noAmbiguousParam,
+				Usage: "exclude characters easy to confuse: " + passgen.Ambiguous,
+			},
+			&cli.IntFlag{
+				Name:    numberParam,
+				Usage:   fmt.Sprintf("number of passwords [1, %d]", passgen.SequencesMax),
+				Aliases: []string{"N"},
+				Value:   1,
+			},
+		},
+		Action: generate(cfg),
+	}
+}
+
+func retrieveParams(cCtx *cli.Context) (passgen.Policy, error) {
+	p := passgen.Policy{
+		MinLength:        cCtx.Int(lengthParam),
+		MaxLength:        cCtx.Int(lengthParam),
+		MinLower:         cCtx.Int(minLowerParam),
+		MinUpper:         cCtx.Int(minUpperParam),
+		MinDigits:        cCtx.Int(minDigitsParam),
+		Symbols:          cCtx.String(symbolsParam),
+		MinSymbols:       cCtx.Int(minSymbolsParam),
+		ExcludeAmbiguous: cCtx.Bool(noAmbiguousParam),
+	}
+
+	rangeSet := cCtx.IsSet(minLengthParam) || cCtx.IsSet(maxLengthParam)
+
+	switch {
+	case rangeSet && cCtx.IsSet(lengthParam):
+		return p, errLengthAndRange
+	case rangeSet:
+		p.MinLength = cCtx.Int(minLengthParam)
+		p.MaxLength = p.MinLength
+
+		if cCtx.IsSet(maxLengthParam) {
+			p.MaxLength = cCtx.Int(maxLengthParam)
+		}
+
+		if !cCtx.IsSet(minLengthParam) {
+			p.MinLength = p.MaxLength
+		}
+	}
+
+	return p, p.Validate() // nolint: wrapcheck
+}
+
+func generate(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		policy, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		var apiInfo entities.APIInfo
+
+		passwords, err := policy.Generate(cCtx.Int(numberParam), Sequences(ctx, cfg, &apiInfo))
+		if err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(Format(passwords), apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Sequences draws sequences with generateIntegerSequences calls and merges
+// their info into apiInfo.
+func Sequences(ctx context.Context, cfg *config.AppConfig, apiInfo *entities.APIInfo) passgen.Sequences {
+	c := cfg.Client()
+
+	return func(seqs []passgen.Seq) ([][]int, error) {
+		params := make([]client.SequenceParams, 0, len(seqs))
+
+		for _, s := range seqs {
+			params = append(params, client.SequenceParams{
+				Length: s.Length,
+				From:   0,
+				To:     int64(s.Max),
+			})
+		}
+
+		values, info, err := c.IntegerSequences(ctx, params)
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		*apiInfo = apiInfo.Merge(info)
+
+		return values, nil
+	}
+}
+
+// Format returns output items with entropy of every secret.
+func Format(secrets []passgen.Secret) []interface{} {
+	outputData := make([]interface{}, 0, len(secrets))
+
+	for _, s := range secrets {
+		outputData = append(outputData, fmt.Sprintf("%s (%.1f bits)", s.Value, s.Bits))
+	}
+
+	return outputData
+}
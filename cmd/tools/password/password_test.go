@@ -0,0 +1,105 @@
+package password_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	passwordcmd "github.com/bohdanch-w/rand-api/cmd/tools/password"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestPasswordCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegerSequences",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegerSequences", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":2,"length":[6,6],
+					"min":[0,0],"max":[61,61],"replacement":[true,true],"base":10,
+					"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[[0, 1, 2, 3, 4, 5], [26, 52, 0, 1, 2, 3]]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"A0abcd (35.0 bits)"}, testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{passwordcmd.NewPasswordCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "password", "-l", "6", "--symbols", "", "--min-upper", "1", "--min-digits", "1"})
+	require.NoError(t, err)
+}
+
+func TestPasswordCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"password", "-l", "8", "--max-length", "10"},
+			expectedError: "length is set by --length OR --min-length/--max-length. Not both",
+		},
+		{
+			args:          []string{"password", "--min-length", "12", "--max-length", "10"},
+			expectedError: "invalid password policy: length must be in [1, 128], min no greater than max",
+		},
+		{
+			args:          []string{"password", "-l", "4", "--min-upper", "3", "--min-digits", "2"},
+			expectedError: "invalid password policy: min counts sum to 5, more than min length 4",
+		},
+		{
+			args:          []string{"password", "-l", "8", "--symbols", "", "--min-symbols", "1"},
+			expectedError: "invalid password policy: min count of class without characters",
+		},
+		{
+			args:          []string{"password", "-l", "6", "--min-digits", "6"},
+			expectedError: "password policy is too strict: 0.0006% of random 6 character passwords satisfy it",
+		},
+	}
+
+	for _, tc := range testCases {
+		app := &cli.App{
+			Name:     "test",
+			Commands: []*cli.Command{passwordcmd.NewPasswordCommand(&config.AppConfig{Timeout: time.Second * 5})},
+		}
+
+		err := app.Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
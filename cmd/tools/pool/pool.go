@@ -0,0 +1,182 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/cmd/tools/blob"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pool"
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const (
+	CommandName = "pool"
+	bitsParam   = "bits"
+
+	// batchBits is the largest blob random.org returns in one request.
+	batchBits = 1_048_576
+	bitsMax   = 1_000 * batchBits
+)
+
+const (
+	errPoolDisabled  = entities.Error("entropy pool is disabled")
+	errFillFromLocal = entities.Error("pool can only be filled from randomorg backend")
+	errNotRandomOrg  = entities.Error("refusing to pool bytes not generated by random.org")
+)
+
+// nolint: gomnd
+func NewPoolCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "manage local pool of random.org bytes used by --backend pool",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "fill",
+				Usage: "fetch blobs from random.org and append them to the pool, signed with --signed",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:    bitsParam,
+						Usage:   "number of bits to fetch, rounded up to whole bytes [1, 1048576000]",
+						Aliases: []string{"b"},
+						Value:   8192,
+					},
+				},
+				Action: fill(cfg),
+			},
+			{
+				Name:   "status",
+				Usage:  "show amount and age of pooled bytes",
+				Action: status(cfg),
+			},
+		},
+	}
+}
+
+type fillParams struct {
+	Bits int64
+}
+
+func (p *fillParams) retriveParams(ctx *cli.Context) error {
+	p.Bits = ctx.Int64(bitsParam)
+
+	return p.validate()
+}
+
+func (p *fillParams) validate() error {
+	if err := validation.Validate(
+		p.Bits,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(bitsMax),
+	); err != nil {
+		return fmt.Errorf("`bits` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func fill(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		var params fillParams
+
+		if err := params.retriveParams(cCtx); err != nil {
+			return err
+		}
+
+		if cfg.Pool == nil {
+			return errPoolDisabled
+		}
+
+		if _, ok := cfg.RandRetriever.(*backend.Local); ok {
+			return errFillFromLocal
+		}
+
+		left := (params.Bits + 7) / 8 * 8 // nolint: gomnd
+
+		for left > 0 {
+			size := left
+			if size > batchBits {
+				size = batchBits
+			}
+
+			if err := fetch(cCtx.Context, cfg, size); err != nil {
+				return err
+			}
+
+			left -= size
+		}
+
+		return printStatus(cCtx, cfg.Pool)
+	}
+}
+
+func fetch(ctx context.Context, cfg *config.AppConfig, size int64) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	data, apiInfo, err := blob.Generate(ctx, cfg, blob.Params{Size: size, Number: 1, Signed: cfg.Signed})
+	if err != nil {
+		return err // nolint: wrapcheck
+	}
+
+	// --fallback crypto passes the backend check above but may still answer locally
+	if apiInfo.Source != randapi.SourceName {
+		return fmt.Errorf("%w: got %q", errNotRandomOrg, apiInfo.Source)
+	}
+
+	for _, v := range data {
+		s, _ := v.(string)
+
+		chunk := pool.Chunk{
+			Source:    pool.SourceRandomOrg,
+			RequestID: apiInfo.ID,
+			FetchedAt: apiInfo.Timestamp,
+			Size:      len(s),
+			Data:      []byte(s),
+
+			SerialNumber: apiInfo.SerialNumber,
+			Signature:    apiInfo.Signature,
+		}
+
+		if err := cfg.Pool.Add(chunk); err != nil {
+			return fmt.Errorf("add to pool: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func status(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		if cfg.Pool == nil {
+			return errPoolDisabled
+		}
+
+		return printStatus(cCtx, cfg.Pool)
+	}
+}
+
+func printStatus(cCtx *cli.Context, p *pool.Pool) error {
+	st, err := p.Status()
+	if err != nil {
+		return fmt.Errorf("get pool status: %w", err)
+	}
+
+	fmt.Fprintf(cCtx.App.Writer, "pool:   %s\n", p.Path())
+	fmt.Fprintf(cCtx.App.Writer, "bits:   %d\n", st.Bytes*8) // nolint: gomnd
+	fmt.Fprintf(cCtx.App.Writer, "chunks: %d\n", st.Chunks)
+
+	if st.Chunks > 0 {
+		fmt.Fprintf(cCtx.App.Writer, "oldest: %s\n", st.Oldest.Format(time.RFC3339))
+		fmt.Fprintf(cCtx.App.Writer, "newest: %s\n", st.Newest.Format(time.RFC3339))
+	}
+
+	return nil
+}
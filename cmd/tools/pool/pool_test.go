@@ -0,0 +1,175 @@
+package pool_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	poolcmd "github.com/bohdanch-w/rand-api/cmd/tools/pool"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/pool"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/randapi/fakeserver"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestPoolFillCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateBlobs",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateBlobs", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"","size":64,"n":1,"format":"base64","pregeneratedRandomization":null}`,
+					string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			DoAndReturn(func(context.Context, *entities.RandomRequest) (entities.RandResponseResult, error) {
+				result := testutils.TestRandResult(t, `["Ox8NH7tk4HM="]`)
+				result.Source = randapi.SourceName
+
+				return result, nil
+			}),
+	)
+
+	p := pool.New(filepath.Join(t.TempDir(), "pool.json"))
+
+	appConfig := &config.AppConfig{
+		Timeout:       time.Second * 5,
+		RandRetriever: mockRandRetriever,
+		Pool:          p,
+	}
+
+	var out bytes.Buffer
+
+	app := &cli.App{
+		Name:     "test",
+		Writer:   &out,
+		Commands: []*cli.Command{poolcmd.NewPoolCommand(appConfig)},
+	}
+
+	require.NoError(t, app.Run([]string{"main.go", "pool", "fill", "--bits", "60"}))
+	require.Contains(t, out.String(), "bits:   64\n")
+
+	st, err := p.Status()
+	require.NoError(t, err)
+	require.Equal(t, pool.Status{
+		Bytes:  8,
+		Chunks: 1,
+		Oldest: time.Date(2022, 8, 25, 12, 15, 44, 395, time.UTC),
+		Newest: time.Date(2022, 8, 25, 12, 15, 44, 395, time.UTC),
+	}, st)
+}
+
+func TestPoolFillCommand_FromPool(t *testing.T) {
+	p := pool.New(filepath.Join(t.TempDir(), "pool.json"))
+
+	appConfig := &config.AppConfig{
+		Timeout:       time.Second * 5,
+		RandRetriever: backend.NewPool(p),
+		Pool:          p,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{poolcmd.NewPoolCommand(appConfig)},
+	}
+
+	require.Error(t, app.Run([]string{"main.go", "pool", "fill"}))
+}
+
+func TestPoolFillCommand_Fallback(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close() // nothing listens on the address anymore
+
+	fallback := backend.NewFallback(randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false), nil)
+
+	p := pool.New(filepath.Join(t.TempDir(), "pool.json"))
+
+	appConfig := &config.AppConfig{
+		Timeout:       time.Second * 5,
+		RandRetriever: fallback,
+		Pool:          p,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{poolcmd.NewPoolCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "pool", "fill", "--bits", "64"})
+	require.EqualError(t, err, `refusing to pool bytes not generated by random.org: got "crypto-fallback"`)
+	require.True(t, fallback.Used())
+
+	st, err := p.Status()
+	require.NoError(t, err)
+	require.Zero(t, st.Chunks)
+}
+
+func TestPoolFillCommand_Signed(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024) // nolint: gosec
+	require.NoError(t, err)
+
+	fs, err := fakeserver.New(fakeserver.Options{SigningKey: key})
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(fs)
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "pool.json")
+
+	appConfig := &config.AppConfig{
+		APIKey:        "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:       time.Second * 5,
+		Signed:        true,
+		RandRetriever: randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false),
+		Pool:          pool.New(path),
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Writer:   io.Discard,
+		Commands: []*cli.Command{poolcmd.NewPoolCommand(appConfig)},
+	}
+
+	require.NoError(t, app.Run([]string{"main.go", "pool", "fill", "--bits", "64"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	chunk := gjson.GetBytes(data, "chunks.0")
+	require.Equal(t, int64(8), chunk.Get("size").Int())
+	require.Equal(t, int64(1), chunk.Get("serialNumber").Int())
+	require.NotEmpty(t, chunk.Get("signature").String())
+}
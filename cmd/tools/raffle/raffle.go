@@ -0,0 +1,192 @@
+package raffle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/choose"
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/raffle"
+)
+
+const (
+	CommandName  = "raffle"
+	winnersParam = "winners"
+	reportParam  = "report"
+	forceParam   = "force"
+
+	defaultReport = "raffle-report.json"
+	stdinFile     = "-"
+)
+
+const (
+	errParticipantsFile = entities.Error("exactly one participants file is required")
+	errReportExists     = entities.Error("report file already exists")
+)
+
+func NewRaffleCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "draw distinct winners from CSV file of NAME[,TICKETS] rows and write audit report",
+		ArgsUsage: "PARTICIPANTS.csv",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    winnersParam,
+				Usage:   "number of winners",
+				Aliases: []string{"w"},
+				Value:   1,
+			},
+			&cli.StringFlag{
+				Name:  reportParam,
+				Usage: "JSON report with file hash, random values, request ids and winners",
+				Value: defaultReport,
+			},
+			&cli.BoolFlag{
+				Name:  forceParam,
+				Usage: "overwrite existing report",
+			},
+		},
+		Action: draw(cfg),
+	}
+}
+
+type Params struct {
+	File         string
+	Participants raffle.Participants
+	Winners      int
+}
+
+func retrieveParams(cCtx *cli.Context) (Params, error) {
+	if cCtx.NArg() != 1 {
+		return Params{}, errParticipantsFile
+	}
+
+	p := Params{
+		File:    cCtx.Args().First(),
+		Winners: cCtx.Int(winnersParam),
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if p.File == stdinFile {
+		data, err = io.ReadAll(cCtx.App.Reader)
+	} else {
+		data, err = os.ReadFile(p.File)
+	}
+
+	if err != nil {
+		return p, fmt.Errorf("read participants: %w", err)
+	}
+
+	if p.Participants, err = raffle.ReadParticipants(data); err != nil {
+		return p, fmt.Errorf("read participants: %w", err)
+	}
+
+	return p, nil
+}
+
+func draw(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		params, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		path := cCtx.String(reportParam)
+
+		f, err := raffle.CreateReport(path, cCtx.Bool(forceParam))
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%w: %s, use --%s to overwrite it or --%s to save elsewhere",
+				errReportExists, path, forceParam, reportParam)
+		}
+
+		if err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		defer f.Close()
+
+		res, calls, apiInfo, err := Draw(ctx, cfg, params)
+		if err != nil {
+			_ = os.Remove(path)
+
+			return err
+		}
+
+		report := raffle.NewReport(params.File, params.Participants, res, calls, time.Now())
+		if err := report.Write(f); err != nil {
+			return err // nolint: wrapcheck
+		}
+
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("close report: %w", err)
+		}
+
+		outputData := make([]interface{}, 0, len(report.Winners))
+		for _, w := range report.Winners {
+			outputData = append(outputData, w.Name)
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Draw draws winners weighted by tickets and returns every call made for the report.
+func Draw(
+	ctx context.Context,
+	cfg *config.AppConfig,
+	params Params,
+) (choose.Result, []raffle.Call, entities.APIInfo, error) {
+	var (
+		c       = cfg.Client()
+		calls   []raffle.Call
+		apiInfo entities.APIInfo
+	)
+
+	ints := func(n int) ([]int, error) {
+		values, info, err := c.Integers(ctx, client.IntegerParams{
+			From:   0,
+			To:     choose.RangeMax - 1,
+			Number: n,
+		})
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		calls = append(calls, raffle.Call{
+			ID:             info.ID,
+			CompletionTime: info.Timestamp,
+			Source:         info.Source,
+			Values:         values,
+		})
+		apiInfo = apiInfo.Merge(info)
+
+		return values, nil
+	}
+
+	res, err := choose.Draw(params.Participants.Entries, params.Winners, false, ints)
+	if err != nil {
+		return choose.Result{}, nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return res, calls, apiInfo, nil
+}
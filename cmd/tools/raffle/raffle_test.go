@@ -0,0 +1,205 @@
+package raffle_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	rafflecmd "github.com/bohdanch-w/rand-api/cmd/tools/raffle"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/raffle"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func newApp(cfg *config.AppConfig, stdin string) *cli.App {
+	return &cli.App{
+		Name:     "test",
+		Reader:   strings.NewReader(stdin),
+		Commands: []*cli.Command{rafflecmd.NewRaffleCommand(cfg)},
+	}
+}
+
+func TestRaffleCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":2,"min":0,"max":999999999,
+					"replacement":true,"base":10,"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		// 10 mod 6 = 4 selects carol of alice:3 bob:1 carol:2,
+		// 3 mod 4 = 3 selects bob of alice:3 bob:1
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[10, 3]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"carol", "bob"}, testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	var (
+		dir          = t.TempDir()
+		participants = filepath.Join(dir, "participants.csv")
+		reportPath   = filepath.Join(dir, "report.json")
+	)
+
+	require.NoError(t, os.WriteFile(participants, []byte("name,tickets\nalice,3\nbob\ncarol,2\nALICE,1\n"), 0o600))
+
+	err := newApp(appConfig, "").
+		Run([]string{"main.go", "raffle", "--winners", "2", "--report", reportPath, participants})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+
+	var report raffle.Report
+
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.WithinDuration(t, time.Now(), report.DrawnAt, time.Minute)
+
+	report.DrawnAt = time.Time{}
+
+	require.Equal(t, raffle.Report{
+		File:         participants,
+		SHA256:       "d975c988c49575d59531da05c5f8d2fc0b1ac64af9bdfd9598cb3839a6ff3f2d",
+		Participants: 3,
+		Tickets:      6,
+		Duplicates:   []string{"ALICE"},
+		RangeMax:     1_000_000_000,
+		Calls: []raffle.Call{{
+			ID:             req.ID,
+			CompletionTime: testutils.TestRandAPIInfo(t, req.ID).Timestamp,
+			Values:         []int{10, 3},
+		}},
+		Winners: []raffle.Winner{
+			{Name: "carol", Index: 2, Tickets: 2},
+			{Name: "bob", Index: 1, Tickets: 1},
+		},
+	}, report)
+}
+
+func TestRaffleCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		stdin         string
+		expectedError string
+	}{
+		{
+			args:          []string{"raffle"},
+			expectedError: "exactly one participants file is required",
+		},
+		{
+			args:          []string{"raffle", "a.csv", "b.csv"},
+			expectedError: "exactly one participants file is required",
+		},
+		{
+			args:          []string{"raffle", "-w", "3", "-"},
+			stdin:         "alice\nbob\nBob\n",
+			expectedError: "invalid pick: 3 of 2 items",
+		},
+		{
+			args:          []string{"raffle", "-"},
+			stdin:         "alice,x\n",
+			expectedError: `read participants: row 1: invalid participant: "alice" tickets must be positive integer`,
+		},
+		{
+			args:          []string{"raffle", "-"},
+			stdin:         "name,tickets\n",
+			expectedError: "no items to choose from",
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{Timeout: time.Second * 5}
+
+		err := newApp(appConfig, tc.stdin).Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestRaffleCommand_ReportExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		dir        = t.TempDir()
+		reportPath = filepath.Join(dir, "report.json")
+	)
+
+	require.NoError(t, os.WriteFile(reportPath, []byte("earlier draw\n"), 0o600))
+
+	// nothing is drawn when the report can't be saved
+	appConfig := &config.AppConfig{
+		Timeout:       time.Second * 5,
+		RandRetriever: mock.NewMockRandRetiever(ctrl),
+	}
+
+	err := newApp(appConfig, "alice\nbob\n").Run([]string{"main.go", "raffle", "--report", reportPath, "-"})
+	require.EqualError(t, err, "report file already exists: "+reportPath+
+		", use --force to overwrite it or --report to save elsewhere")
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	require.Equal(t, "earlier draw\n", string(data))
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Return(entities.RandomRequest{}, nil),
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), gomock.Any()).
+			Return(testutils.TestRandResult(t, `[1]`), nil),
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"bob"}, gomock.Any()).
+			Return(nil),
+	)
+
+	appConfig = &config.AppConfig{
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err = newApp(appConfig, "alice\nbob\n").Run([]string{"main.go", "raffle", "--report", reportPath, "--force", "-"})
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(reportPath)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"name": "bob"`)
+}
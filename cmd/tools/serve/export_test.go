@@ -0,0 +1,24 @@
+package serve
+
+import "time"
+
+// NewTestLimiter exposes limiter with a fake clock to tests.
+func NewTestLimiter(perMinute, burst int, now func() time.Time) (func(client string) bool, func() int) {
+	l := newLimiter(perMinute, burst)
+	l.now = now
+
+	allow := func(client string) bool {
+		ok, _ := l.allow(client)
+
+		return ok
+	}
+
+	size := func() int {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		return len(l.clients)
+	}
+
+	return allow, size
+}
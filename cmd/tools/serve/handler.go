@@ -0,0 +1,377 @@
+package serve
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/blob"
+	"github.com/bohdanch-w/rand-api/cmd/tools/coin"
+	"github.com/bohdanch-w/rand-api/cmd/tools/decimal"
+	"github.com/bohdanch-w/rand-api/cmd/tools/gausian"
+	"github.com/bohdanch-w/rand-api/cmd/tools/integer"
+	randstr "github.com/bohdanch-w/rand-api/cmd/tools/string"
+	randuuid "github.com/bohdanch-w/rand-api/cmd/tools/uuid"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+// Options configure access to the server.
+type Options struct {
+	// Tokens accepted in `Authorization: Bearer` header. Empty disables auth.
+	Tokens []string
+	// RatePerMinute limits requests of each client. Zero disables limiting.
+	RatePerMinute int
+	Burst         int
+}
+
+type generator func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error)
+
+// endpoint parses and validates query into a generator.
+type endpoint func(q *query) (generator, error)
+
+func NewHandler(cfg *config.AppConfig, opts Options) http.Handler {
+	h := &handler{
+		cfg:    cfg,
+		tokens: opts.Tokens,
+	}
+
+	if opts.RatePerMinute > 0 {
+		h.limiter = newLimiter(opts.RatePerMinute, opts.Burst)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /v1/integers", h.guard(h.generate(integers)))
+	mux.Handle("GET /v1/decimals", h.guard(h.generate(decimals)))
+	mux.Handle("GET /v1/gaussians", h.guard(h.generate(gaussians)))
+	mux.Handle("GET /v1/strings", h.guard(h.generate(strs)))
+	mux.Handle("GET /v1/uuids", h.guard(h.generate(uuids)))
+	mux.Handle("GET /v1/blobs", h.guard(h.generate(blobs)))
+	mux.Handle("GET /v1/coins", h.guard(h.generate(coins)))
+	mux.Handle("GET /v1/usage", h.guard(http.HandlerFunc(h.usage)))
+
+	return mux
+}
+
+type handler struct {
+	cfg     *config.AppConfig
+	tokens  []string
+	limiter *limiter
+}
+
+type randResponse struct {
+	Data           []interface{} `json:"data"`
+	ID             uuid.UUID     `json:"id"`
+	CompletionTime time.Time     `json:"completionTime"`
+	BitsUsed       uint64        `json:"bitsUsed"`
+	BitsLeft       uint64        `json:"bitsLeft"`
+	RequestsLeft   uint64        `json:"requestsLeft"`
+	Source         string        `json:"source"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// guard authenticates client and applies rate limit.
+func (h *handler) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, ok := h.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid or missing token"})
+
+			return
+		}
+
+		if h.limiter != nil {
+			if allowed, wait := h.limiter.allow(client); !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate limit exceeded"})
+
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate returns client identity: token when auth is enabled, remote host otherwise.
+func (h *handler) authenticate(r *http.Request) (string, bool) {
+	if len(h.tokens) == 0 {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			return r.RemoteAddr, true
+		}
+
+		return host, true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", false
+	}
+
+	for _, t := range h.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return t, true
+		}
+	}
+
+	return "", false
+}
+
+func (h *handler) generate(e endpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gen, err := e(&query{values: r.URL.Query()})
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.Timeout)
+		defer cancel()
+
+		data, apiInfo, err := gen(ctx, h.cfg)
+		if err != nil {
+			writeJSON(w, http.StatusBadGateway, errorResponse{Error: err.Error()})
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, randResponse{
+			Data:           data,
+			ID:             apiInfo.ID,
+			CompletionTime: apiInfo.Timestamp,
+			BitsUsed:       apiInfo.BitsUsed,
+			BitsLeft:       apiInfo.BitsLeft,
+			RequestsLeft:   apiInfo.RequestsLeft,
+			Source:         apiInfo.Source,
+		})
+	})
+}
+
+func (h *handler) usage(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.Timeout)
+	defer cancel()
+
+	usage, err := h.cfg.RandRetriever.GetUsage(ctx, h.cfg.APIKey)
+	if err != nil {
+		writeJSON(w, http.StatusBadGateway, errorResponse{Error: fmt.Sprintf("get usage: %s", err)})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, usage)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func integers(q *query) (generator, error) {
+	p := integer.DefaultParams()
+	p.From = q.int64(p.From, "from", "min")
+	p.To = q.int64(p.To, "to", "max")
+	p.Number = q.int(p.Number, "number", "n")
+	p.Unique = q.bool(p.Unique, "unique")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return integer.Generate(ctx, cfg, p)
+	})
+}
+
+func decimals(q *query) (generator, error) {
+	p := decimal.DefaultParams()
+	p.Base = q.float64(p.Base, "base")
+	p.Places = q.int(p.Places, "places", "decimalPlaces")
+	p.Number = q.int(p.Number, "number", "n")
+	p.Unique = q.bool(p.Unique, "unique")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return decimal.Generate(ctx, cfg, p)
+	})
+}
+
+func gaussians(q *query) (generator, error) {
+	p := gausian.DefaultParams()
+	p.Mean = q.float64(p.Mean, "mean")
+	p.Deviation = q.float64(p.Deviation, "deviation", "standardDeviation")
+	p.SignificantDigits = q.int(p.SignificantDigits, "signdig", "significantDigits")
+	p.Number = q.int(p.Number, "number", "n")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return gausian.Generate(ctx, cfg, p)
+	})
+}
+
+func strs(q *query) (generator, error) {
+	p := randstr.DefaultParams()
+	p.Length = q.int(p.Length, "length")
+	p.Charset = randstr.ResolveCharset(q.string(p.Charset, "charset", "characters"))
+	p.Number = q.int(p.Number, "number", "n")
+	p.Unique = q.bool(p.Unique, "unique")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return randstr.Generate(ctx, cfg, p)
+	})
+}
+
+func uuids(q *query) (generator, error) {
+	p := randuuid.DefaultParams()
+	p.Number = q.int(p.Number, "number", "n")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return randuuid.Generate(ctx, cfg, p)
+	})
+}
+
+func blobs(q *query) (generator, error) {
+	p := blob.DefaultParams()
+	p.Size = q.int64(p.Size, "size")
+	p.Number = q.int(p.Number, "number", "n")
+	p.Hex = q.bool(p.Hex, "hex")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		data, apiInfo, err := blob.Generate(ctx, cfg, p)
+		if err != nil {
+			return nil, apiInfo, err
+		}
+
+		// raw bytes are not valid JSON strings, so blobs are sent in requested format
+		encode := base64.StdEncoding.EncodeToString
+		if p.Hex {
+			encode = hex.EncodeToString
+		}
+
+		for i, v := range data {
+			data[i] = encode([]byte(v.(string))) // nolint: forcetypeassert
+		}
+
+		return data, apiInfo, nil
+	})
+}
+
+func coins(q *query) (generator, error) {
+	p := coin.DefaultParams()
+	p.Format = q.string(p.Format, "format")
+	p.Number = q.int(p.Number, "number", "n")
+
+	return validated(q, &p, func(ctx context.Context, cfg *config.AppConfig) ([]interface{}, entities.APIInfo, error) {
+		return coin.Generate(ctx, cfg, p)
+	})
+}
+
+type validator interface {
+	Validate() error
+}
+
+func validated(q *query, params validator, gen generator) (generator, error) {
+	if err := q.err; err != nil {
+		return nil, err
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err // nolint: wrapcheck
+	}
+
+	return gen, nil
+}
+
+// query reads typed values from url query. First parse error is kept in err.
+type query struct {
+	values url.Values
+	err    error
+}
+
+func (q *query) lookup(names []string) (string, string, bool) {
+	for _, name := range names {
+		if q.values.Has(name) {
+			return name, q.values.Get(name), true
+		}
+	}
+
+	return "", "", false
+}
+
+func (q *query) setErr(name string, err error) {
+	if q.err == nil {
+		q.err = fmt.Errorf("`%s` param is invalid: %w", name, err)
+	}
+}
+
+func (q *query) string(def string, names ...string) string {
+	if _, v, ok := q.lookup(names); ok {
+		return v
+	}
+
+	return def
+}
+
+func (q *query) int(def int, names ...string) int {
+	return int(q.int64(int64(def), names...))
+}
+
+func (q *query) int64(def int64, names ...string) int64 {
+	name, v, ok := q.lookup(names)
+	if !ok {
+		return def
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		q.setErr(name, err)
+	}
+
+	return n
+}
+
+func (q *query) float64(def float64, names ...string) float64 {
+	name, v, ok := q.lookup(names)
+	if !ok {
+		return def
+	}
+
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		q.setErr(name, err)
+	}
+
+	return n
+}
+
+func (q *query) bool(def bool, names ...string) bool {
+	name, v, ok := q.lookup(names)
+	if !ok {
+		return def
+	}
+
+	if v == "" {
+		return true
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		q.setErr(name, err)
+	}
+
+	return b
+}
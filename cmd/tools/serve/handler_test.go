@@ -0,0 +1,240 @@
+package serve_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/serve"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+const apiKey = "c6418ada-7874-4907-9367-f43c446686d3" // nolint: gosec
+
+func newServer(t *testing.T, retriever *mock.MockRandRetiever, opts serve.Options) *httptest.Server {
+	t.Helper()
+
+	cfg := &config.AppConfig{
+		APIKey:        apiKey,
+		Timeout:       time.Second * 5,
+		RandRetriever: retriever,
+	}
+
+	ts := httptest.NewServer(serve.NewHandler(cfg, opts))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func get(t *testing.T, url, token string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	return resp, body
+}
+
+func TestHandler_Integers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:             uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		JsonrpcVersion: "3.0",
+		Method:         "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encParams, err := json.Marshal(params)
+				require.NoError(t, err)
+
+				require.JSONEq(t,
+					`{"apiKey":"`+apiKey+`","n":3,"min":1,"max":6,"replacement":true,"base":10,"pregeneratedRandomization":null}`,
+					string(encParams),
+				)
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			DoAndReturn(func(context.Context, *entities.RandomRequest) (entities.RandResponseResult, error) {
+				result := testutils.TestRandResult(t, `[1, 4, 6]`)
+				result.Source = "randomorg"
+
+				return result, nil
+			}),
+	)
+
+	ts := newServer(t, mockRandRetriever, serve.Options{})
+
+	resp, body := get(t, ts.URL+"/v1/integers?min=1&max=6&n=3", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []interface{}{float64(1), float64(4), float64(6)}, body["data"])
+	require.Equal(t, req.ID.String(), body["id"])
+	require.Equal(t, float64(150), body["bitsUsed"])
+	require.Equal(t, float64(1477), body["bitsLeft"])
+	require.Equal(t, float64(233), body["requestsLeft"])
+	require.Equal(t, "randomorg", body["source"])
+}
+
+func TestHandler_Blobs(t *testing.T) {
+	testCases := []struct {
+		query    string
+		random   string
+		expected string
+	}{
+		// bytes ff 00 fe 80 are not valid UTF-8
+		{query: "size=32", random: `["/wD+gA=="]`, expected: "/wD+gA=="},
+		{query: "size=32&hex=true", random: `["ff00fe80"]`, expected: "ff00fe80"},
+	}
+
+	for _, tc := range testCases {
+		ctrl := gomock.NewController(t)
+
+		req := entities.RandomRequest{
+			ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+			Method: "generateBlobs",
+		}
+
+		mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+		gomock.InOrder(
+			mockRandRetriever.EXPECT().NewRequest("generateBlobs", gomock.Any()).Return(req, nil),
+			mockRandRetriever.EXPECT().
+				ExecuteRequest(gomock.Any(), &req).
+				Return(testutils.TestRandResult(t, tc.random), nil),
+		)
+
+		ts := newServer(t, mockRandRetriever, serve.Options{})
+
+		resp, body := get(t, ts.URL+"/v1/blobs?"+tc.query, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []interface{}{tc.expected}, body["data"], tc.query)
+
+		ctrl.Finish()
+	}
+}
+
+func TestHandler_BadParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := newServer(t, mock.NewMockRandRetiever(ctrl), serve.Options{})
+
+	testcases := []struct {
+		path        string
+		expectedErr string
+	}{
+		{path: "/v1/integers?n=abc", expectedErr: "`n` param is invalid: strconv.ParseInt: parsing \"abc\": invalid syntax"},
+		{path: "/v1/coins?number=0"},
+		{path: "/v1/strings?charset=&length=5"},
+		{path: "/v1/decimals?unique=maybe"},
+	}
+
+	for _, tc := range testcases {
+		resp, body := get(t, ts.URL+tc.path, "")
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, tc.path)
+		require.NotEmpty(t, body["error"], tc.path)
+
+		if tc.expectedErr != "" {
+			require.Equal(t, tc.expectedErr, body["error"], tc.path)
+		}
+	}
+}
+
+func TestHandler_GenerateFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockRandRetriever.EXPECT().
+		NewRequest("generateUUIDs", gomock.Any()).
+		Return(entities.RandomRequest{}, entities.Error("test error"))
+
+	ts := newServer(t, mockRandRetriever, serve.Options{})
+
+	resp, body := get(t, ts.URL+"/v1/uuids", "")
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.Contains(t, body["error"], "test error")
+}
+
+func TestHandler_Auth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockRandRetriever.EXPECT().
+		GetUsage(gomock.Any(), apiKey).
+		Return(entities.UsageStatus{Status: "running", BitsLeft: 1000, RequestsLeft: 50}, nil)
+
+	ts := newServer(t, mockRandRetriever, serve.Options{Tokens: []string{"secret"}})
+
+	resp, _ := get(t, ts.URL+"/v1/usage", "")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+
+	resp, _ = get(t, ts.URL+"/v1/usage", "wrong")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, body := get(t, ts.URL+"/v1/usage", "secret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, body)
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockRandRetriever.EXPECT().
+		GetUsage(gomock.Any(), apiKey).
+		Return(entities.UsageStatus{}, nil).
+		Times(2)
+
+	ts := newServer(t, mockRandRetriever, serve.Options{
+		Tokens:        []string{"first", "second"},
+		RatePerMinute: 1,
+		Burst:         1,
+	})
+
+	resp, _ := get(t, ts.URL+"/v1/usage", "first")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body := get(t, ts.URL+"/v1/usage", "first")
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, "60", resp.Header.Get("Retry-After"))
+	require.Equal(t, "rate limit exceeded", body["error"])
+
+	// limit is tracked per client
+	resp, _ = get(t, ts.URL+"/v1/usage", "second")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,84 @@
+package serve
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+func newLimiter(perMinute, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	rate := float64(perMinute) / time.Minute.Seconds()
+
+	return &limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		idle:    time.Duration(float64(burst) / rate * float64(time.Second)),
+		clients: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// limiter is a token bucket per client. Buckets idle long enough to refill
+// are the same as new ones, so they are evicted to keep memory bounded.
+type limiter struct {
+	rate  float64
+	burst float64
+	// idle is time an empty bucket takes to refill.
+	idle      time.Duration
+	clients   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a token from client bucket. When bucket is empty
+// it reports how long client should wait for the next token.
+func (l *limiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if now.Sub(l.lastSweep) >= l.idle {
+		l.sweep(now)
+	}
+
+	b, ok := l.clients[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.clients[client] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+
+	return false, wait
+}
+
+// sweep evicts buckets of clients not seen for idle time.
+func (l *limiter) sweep(now time.Time) {
+	for client, b := range l.clients {
+		if now.Sub(b.last) >= l.idle {
+			delete(l.clients, client)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,46 @@
+package serve_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/serve"
+)
+
+func TestLimiter_EvictsIdleClients(t *testing.T) {
+	now := time.Date(2022, 8, 25, 12, 0, 0, 0, time.UTC)
+
+	// 2 tokens refill in 2 minutes
+	allow, size := serve.NewTestLimiter(1, 2, func() time.Time { return now })
+
+	for i := 0; i < 100; i++ {
+		require.True(t, allow(fmt.Sprintf("client-%d", i)))
+	}
+
+	require.True(t, allow("active"))
+	require.True(t, allow("active"))
+	require.False(t, allow("active"))
+	require.Equal(t, 101, size())
+
+	now = now.Add(time.Minute)
+
+	require.True(t, allow("active"))
+	require.Equal(t, 101, size(), "buckets are kept until they could refill")
+
+	now = now.Add(90 * time.Second)
+
+	require.True(t, allow("new"))
+	require.Equal(t, 2, size(), "idle clients are evicted")
+
+	// active client keeps its bucket with 1.5 tokens
+	require.True(t, allow("active"))
+	require.False(t, allow("active"))
+
+	// evicted bucket is the same as a full one
+	require.True(t, allow("client-0"))
+	require.True(t, allow("client-0"))
+	require.False(t, allow("client-0"))
+}
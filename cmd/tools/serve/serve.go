@@ -0,0 +1,122 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/tracing"
+)
+
+const (
+	CommandName = "serve"
+	addrParam   = "addr"
+	tokenParam  = "token"
+	rateParam   = "rate"
+	burstParam  = "burst"
+
+	defaultAddr       = "127.0.0.1:8080"
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// nolint: gomnd
+func NewServeCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "serve generators as REST API on /v1/{integers,decimals,gaussians,strings,uuids,blobs,coins,usage}",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    addrParam,
+				Usage:   "address to listen on",
+				Aliases: []string{"a"},
+				Value:   defaultAddr,
+			},
+			&cli.StringSliceFlag{
+				Name:    tokenParam,
+				Usage:   "accepted bearer token. May be repeated. No auth if unset",
+				EnvVars: []string{"RANDAPI_SERVE_TOKENS"},
+			},
+			&cli.IntFlag{
+				Name:  rateParam,
+				Usage: "requests per minute allowed for each client, 0 - unlimited",
+				Value: 0,
+			},
+			&cli.IntFlag{
+				Name:  burstParam,
+				Usage: "requests each client may make at once before rate limit applies",
+				Value: 10,
+			},
+		},
+		Action: serve(cfg),
+	}
+}
+
+func serve(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		opts := Options{
+			Tokens:        cCtx.StringSlice(tokenParam),
+			RatePerMinute: cCtx.Int(rateParam),
+			Burst:         cCtx.Int(burstParam),
+		}
+
+		if err := validation.Validate(opts.RatePerMinute, validation.Min(0)); err != nil {
+			return fmt.Errorf("`rate` param is invalid: %w", err)
+		}
+
+		if err := validation.Validate(opts.Burst, validation.Min(1)); err != nil {
+			return fmt.Errorf("`burst` param is invalid: %w", err)
+		}
+
+		srv := &http.Server{
+			Addr:              cCtx.String(addrParam),
+			Handler:           cfg.Metrics.Expose(tracing.Handler(NewHandler(cfg, opts), CommandName)),
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+
+		return ListenAndServe(cCtx.Context, srv)
+	}
+}
+
+// ListenAndServe runs srv until ctx is done or process is interrupted.
+func ListenAndServe(ctx context.Context, srv *http.Server) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		log.Printf("listening on %s\n", srv.Addr)
+
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("listen: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package shuffle
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	CommandName   = "shuffle"
+	pickParam     = "pick"
+	inputParam    = "input"
+	seedFileParam = "seed-file"
+
+	itemsMax     = 10_000
+	seedFilePerm = 0o600
+)
+
+const errInputAndArgs = entities.Error("items are read from args OR input file. Not both")
+
+func NewShuffleCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "output items in random order. Items are args, lines of input file or stdin",
+		ArgsUsage: "[ITEM...]",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        pickParam,
+				Usage:       "output only first K items of the permutation",
+				Aliases:     []string{"k"},
+				DefaultText: "all",
+			},
+			&cli.StringFlag{
+				Name:    inputParam,
+				Usage:   "file with one item per line, - for stdin",
+				Aliases: []string{"i"},
+			},
+			&cli.StringFlag{
+				Name:  seedFileParam,
+				Usage: "save permutation indices with request id and time to JSON file for audit",
+			},
+		},
+		Action: shuffle(cfg),
+	}
+}
+
+type Params struct {
+	Items []string
+	// Pick is number of items returned, all if 0.
+	Pick int
+}
+
+func retrieveParams(cCtx *cli.Context) (Params, error) {
+	p := Params{
+		Items: cCtx.Args().Slice(),
+		Pick:  cCtx.Int(pickParam),
+	}
+
+	input := cCtx.String(inputParam)
+
+	switch {
+	case input != "" && len(p.Items) > 0:
+		return p, errInputAndArgs
+	case len(p.Items) > 0:
+	case input == "" || input == "-":
+		items, err := readItems(cCtx.App.Reader)
+		if err != nil {
+			return p, err
+		}
+
+		p.Items = items
+	default:
+		f, err := os.Open(input)
+		if err != nil {
+			return p, fmt.Errorf("open input: %w", err)
+		}
+
+		defer f.Close()
+
+		if p.Items, err = readItems(f); err != nil {
+			return p, err
+		}
+	}
+
+	return p, p.Validate()
+}
+
+// readItems returns non-empty lines of r.
+func readItems(r io.Reader) ([]string, error) {
+	var items []string
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if item := strings.TrimRight(scanner.Text(), "\r"); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (p *Params) Validate() error {
+	if err := validation.Validate(
+		len(p.Items),
+		validation.Required.Error("no items to shuffle"),
+		validation.Max(itemsMax),
+	); err != nil {
+		return fmt.Errorf("items are invalid: %w", err)
+	}
+
+	if err := validation.Validate(p.Pick, validation.Min(0), validation.Max(len(p.Items))); err != nil {
+		return fmt.Errorf("`pick` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func shuffle(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
+		defer cancel()
+
+		params, err := retrieveParams(cCtx)
+		if err != nil {
+			return err
+		}
+
+		indices, apiInfo, err := Permutation(ctx, cfg, len(params.Items), params.Pick)
+		if err != nil {
+			return err
+		}
+
+		if path := cCtx.String(seedFileParam); path != "" {
+			if err := writeSeedFile(path, len(params.Items), indices, apiInfo); err != nil {
+				return err
+			}
+		}
+
+		outputData := make([]interface{}, 0, len(indices))
+		for _, i := range indices {
+			outputData = append(outputData, params.Items[i])
+		}
+
+		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
+			return fmt.Errorf("generate rand output: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Permutation returns first pick (all if 0) indices of uniformly random permutation
+// of n items, drawn as unique integers in [0, n-1].
+func Permutation(ctx context.Context, cfg *config.AppConfig, n, pick int) ([]int, entities.APIInfo, error) {
+	if pick == 0 {
+		pick = n
+	}
+
+	if n == 1 {
+		return []int{0}, entities.APIInfo{}, nil
+	}
+
+	indices, apiInfo, err := cfg.Client().Integers(ctx, client.IntegerParams{
+		From:   0,
+		To:     int64(n - 1),
+		Number: pick,
+		Unique: true,
+	})
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
+
+	return indices, apiInfo, nil
+}
+
+// seedFile is audit record of a shuffle.
+type seedFile struct {
+	ID             uuid.UUID `json:"id"`
+	CompletionTime time.Time `json:"completionTime"`
+	Source         string    `json:"source"`
+	Items          int       `json:"items"`
+	// Indices are 0-based positions of input items in output order.
+	Indices []int `json:"indices"`
+}
+
+func writeSeedFile(path string, items int, indices []int, apiInfo entities.APIInfo) error {
+	data, err := json.MarshalIndent(seedFile{
+		ID:             apiInfo.ID,
+		CompletionTime: apiInfo.Timestamp,
+		Source:         apiInfo.Source,
+		Items:          items,
+		Indices:        indices,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode seed file: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), seedFilePerm); err != nil {
+		return fmt.Errorf("write seed file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package shuffle_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/cmd/tools/shuffle"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func newApp(cfg *config.AppConfig, stdin string) *cli.App {
+	return &cli.App{
+		Name:     "test",
+		Reader:   strings.NewReader(stdin),
+		Commands: []*cli.Command{shuffle.NewShuffleCommand(cfg)},
+	}
+}
+
+func TestShuffleCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateIntegers",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateIntegers", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":4,"min":0,"max":3,
+					"replacement":false,"base":10,"pregeneratedRandomization":null}`, string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `[2, 0, 3, 1]`), nil),
+
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"carol", "alice", "dave", "bob"}, testutils.TestRandAPIInfo(t, req.ID)).
+			Return(nil),
+	)
+
+	appConfig := &config.AppConfig{
+		APIKey:          "c6418ada-7874-4907-9367-f43c446686d3",
+		Timeout:         time.Second * 5,
+		RandRetriever:   mockRandRetriever,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	seedFile := filepath.Join(t.TempDir(), "seed.json")
+
+	err := newApp(appConfig, "alice\nbob\r\n\ncarol\ndave\n").
+		Run([]string{"main.go", "shuffle", "--seed-file", seedFile})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(seedFile)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": "71d996a7-ff3f-4ba1-84bb-f4cad27eafb6",
+		"completionTime": "2022-08-25T12:15:44.000000395Z",
+		"source": "",
+		"items": 4,
+		"indices": [2, 0, 3, 1]
+	}`, string(data))
+}
+
+func TestShuffleCommand_PickFromFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := filepath.Join(t.TempDir(), "items.txt")
+	require.NoError(t, os.WriteFile(input, []byte("a\nb\nc\nd\ne\n"), 0o600))
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	var picked []any
+
+	mockOutputProcessor.EXPECT().
+		GenerateRandOutput(gomock.Any(), gomock.Any()).
+		Do(func(data []any, _ entities.APIInfo) { picked = data }).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		Timeout:         time.Second * 5,
+		RandRetriever:   backend.NewSeeded("shuffle"),
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "").Run([]string{"main.go", "shuffle", "--pick", "2", "-i", input})
+	require.NoError(t, err)
+	require.Len(t, picked, 2)
+	require.NotEqual(t, picked[0], picked[1])
+	require.Subset(t, []any{"a", "b", "c", "d", "e"}, picked)
+}
+
+func TestShuffleCommand_SingleItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	mockOutputProcessor.EXPECT().
+		GenerateRandOutput([]any{"only"}, entities.APIInfo{}).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		Timeout:         time.Second * 5,
+		OutputProcessor: mockOutputProcessor,
+	}
+
+	err := newApp(appConfig, "").Run([]string{"main.go", "shuffle", "only"})
+	require.NoError(t, err)
+}
+
+func TestShuffleCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		stdin         string
+		expectedError string
+	}{
+		{
+			args:          []string{"shuffle"},
+			expectedError: "items are invalid: no items to shuffle",
+		},
+		{
+			args:          []string{"shuffle", "--pick", "3", "a", "b"},
+			expectedError: "`pick` param is invalid: must be no greater than 2",
+		},
+		{
+			args:          []string{"shuffle", "-i", "items.txt", "a"},
+			expectedError: "items are read from args OR input file. Not both",
+		},
+		{
+			args:          []string{"shuffle"},
+			stdin:         strings.Repeat("x\n", 10_001),
+			expectedError: "items are invalid: must be no greater than 10000",
+		},
+	}
+
+	for _, tc := range testCases {
+		appConfig := &config.AppConfig{Timeout: time.Second * 5}
+
+		err := newApp(appConfig, tc.stdin).Run(append([]string{"main.go"}, tc.args...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
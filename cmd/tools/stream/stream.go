@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/client"
+	"github.com/bohdanch-w/rand-api/config"
+)
+
+const (
+	CommandName = "stream"
+	bytesParam  = "bytes"
+	chunkParam  = "chunk"
+
+	bytesMax = 1_000 * client.MaxChunk
+)
+
+func NewStreamCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "write raw random bytes to output, e.g. `randapi stream -b 4096 | dd of=key.bin`",
+		Flags: []cli.Flag{
+			&cli.Int64Flag{
+				Name:     bytesParam,
+				Usage:    fmt.Sprintf("number of bytes written [1, %d]", bytesMax),
+				Aliases:  []string{"b"},
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:        chunkParam,
+				Usage:       fmt.Sprintf("bytes fetched in one request [1, %d]", client.MaxChunk),
+				DefaultText: "min(bytes, max)",
+			},
+		},
+		Action: stream(cfg),
+	}
+}
+
+type params struct {
+	Bytes int64
+	Chunk int
+}
+
+func (p *params) retrieveParams(ctx *cli.Context) error {
+	p.Bytes = ctx.Int64(bytesParam)
+	p.Chunk = ctx.Int(chunkParam)
+
+	if !ctx.IsSet(chunkParam) {
+		p.Chunk = int(min(p.Bytes, client.MaxChunk))
+	}
+
+	return p.Validate()
+}
+
+func (p *params) Validate() error {
+	if err := validation.Validate(
+		p.Bytes,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(bytesMax),
+	); err != nil {
+		return fmt.Errorf("`bytes` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Chunk,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(client.MaxChunk),
+	); err != nil {
+		return fmt.Errorf("`chunk` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func stream(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		var p params
+
+		if err := p.retrieveParams(cCtx); err != nil {
+			return err
+		}
+
+		// timeout applies to every request
+		calls := (p.Bytes + int64(p.Chunk) - 1) / int64(p.Chunk)
+
+		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout*time.Duration(calls))
+		defer cancel()
+
+		r := cfg.Client().NewReader(ctx, p.Chunk)
+
+		if _, err := io.CopyN(cfg.Output, r, p.Bytes); err != nil {
+			return fmt.Errorf("stream random bytes: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package stream_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/cmd/tools/stream"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func runStream(t *testing.T, cfg *config.AppConfig, args ...string) ([]byte, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cfg.Timeout = 5 * time.Second
+	cfg.Output = &out
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{stream.NewStreamCommand(cfg)},
+	}
+
+	err := app.Run(append([]string{"main.go", "stream"}, args...))
+
+	return out.Bytes(), err
+}
+
+func TestStreamCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:     uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		Method: "generateBlobs",
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			NewRequest("generateBlobs", gomock.Any()).
+			Do(func(_ string, params any) {
+				encReq, err := json.Marshal(params)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"apiKey":"","size":40,"n":1,"format":"base64","pregeneratedRandomization":null}`,
+					string(encReq))
+			}).
+			Return(req, nil),
+
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, `["aGVsbG8="]`), nil),
+	)
+
+	out, err := runStream(t, &config.AppConfig{RandRetriever: mockRandRetriever}, "-b", "5")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), out)
+}
+
+func TestStreamCommand_Chunked(t *testing.T) {
+	out, err := runStream(t, &config.AppConfig{RandRetriever: backend.NewSeeded("stream")}, "-b", "1000", "--chunk", "64")
+	require.NoError(t, err)
+	require.Len(t, out, 1000)
+
+	whole, err := runStream(t, &config.AppConfig{RandRetriever: backend.NewSeeded("stream")}, "-b", "1000")
+	require.NoError(t, err)
+	require.Equal(t, whole, out)
+}
+
+func TestStreamCommand_BadParams(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"-b", "0"},
+			expectedError: "`bytes` param is invalid: must be no less than 1",
+		},
+		{
+			args:          []string{"-b", "10", "--chunk", "131073"},
+			expectedError: "`chunk` param is invalid: must be no greater than 131072",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := runStream(t, &config.AppConfig{}, tc.args...)
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestStreamCommand_RetrieveFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+	mockRandRetriever.EXPECT().
+		NewRequest(gomock.Any(), gomock.Any()).
+		Return(entities.RandomRequest{}, entities.Error("test error"))
+
+	_, err := runStream(t, &config.AppConfig{RandRetriever: mockRandRetriever}, "-b", "16")
+	require.ErrorIs(t, err, entities.Error("test error"))
+}
@@ -2,15 +2,11 @@ package string
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
-	"time"
 
-	"github.com/bohdanch-w/datatypes/hashset"
-	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 )
@@ -22,14 +18,12 @@ const (
 	numberParam  = "number"
 	uniqueParam  = "unique"
 
-	method                = "generateStrings"
-	maxStringLen          = 32
-	maxCharsetLen         = 128
-	numberMax             = 10_000
 	defaultCharacterRange = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_"
 )
 
 func NewStringCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:    CommandName,
 		Usage:   "generate random string of given characters",
@@ -39,20 +33,20 @@ func NewStringCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    lengthParam,
 				Usage:   "length of generated strings [1, 32](If N > 1, all strings have same length)",
 				Aliases: []string{"l"},
-				Value:   1,
+				Value:   defaults.Length,
 			},
 			&cli.StringFlag{
 				Name:        charsetParam,
 				Usage:       "characters to be used in generation. Max len - 128",
 				Aliases:     []string{"c"},
-				Value:       defaultCharacterRange,
+				Value:       defaults.Charset,
 				DefaultText: "[A-Za-z0-9_]",
 			},
 			&cli.IntFlag{
 				Name:    numberParam,
 				Usage:   "number of values returned [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 			&cli.BoolFlag{
 				Name:    uniqueParam,
@@ -64,61 +58,48 @@ func NewStringCommand(cfg *config.AppConfig) *cli.Command {
 	}
 }
 
-type stringParams struct {
-	Length  int
-	Charset string
-	Number  int
-	Unique  bool
-}
-
-func (p *stringParams) retriveParams(ctx *cli.Context) error {
-	p.Length = ctx.Int(lengthParam)
-	p.Charset = ctx.String(charsetParam)
-	p.Number = ctx.Int(numberParam)
-	p.Unique = ctx.Bool(uniqueParam)
-
-	p.Charset = string(hashset.New([]rune(charset(p.Charset))...).Values())
+type Params = client.StringParams
 
-	return p.validate()
+func DefaultParams() Params {
+	return Params{
+		Length:  1,
+		Charset: defaultCharacterRange,
+		Number:  1,
+	}
 }
 
-func (p *stringParams) validate() error {
-	const errMaxUniqueRandomExceeded = entities.Error("`number` of unique requested values is greater than possible")
-
-	if err := validation.Validate(
-		p.Length,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(maxStringLen),
-	); err != nil {
-		return fmt.Errorf("`length` param is invalid: %w", err)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		Length:  ctx.Int(lengthParam),
+		Charset: ResolveCharset(ctx.String(charsetParam)),
+		Number:  ctx.Int(numberParam),
+		Unique:  ctx.Bool(uniqueParam),
 	}
 
-	if err := validation.Validate(
-		len(p.Charset),
-		validation.Required.Error("length must be no less than 1"),
-		validation.Max(maxCharsetLen),
-	); err != nil {
-		return fmt.Errorf("`charset` param is invalid: %w", err)
-	}
+	return p, p.Validate()
+}
 
-	if err := validation.Validate(
-		p.Number,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(numberMax),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
+// ResolveCharset expands predefined charset names and removes repeated characters,
+// keeping the first occurrence so the alphabet, and seeded output, are stable.
+// Empty charset stays empty to fail validation, defaults come from flags and query params.
+func ResolveCharset(s string) string {
+	if s == "" {
+		return ""
 	}
 
-	if p.Unique {
-		possibleRand := int(math.Pow(float64(len(p.Charset)), float64(p.Length)))
-		if possibleRand < p.Number {
-			return fmt.Errorf("%w with max possible %d", errMaxUniqueRandomExceeded, possibleRand)
+	var (
+		chars = make([]rune, 0, len(s))
+		seen  = make(map[rune]bool)
+	)
+
+	for _, r := range charset(s) {
+		if !seen[r] {
+			seen[r] = true
+			chars = append(chars, r)
 		}
 	}
 
-	return nil
+	return string(chars)
 }
 
 func randString(cfg *config.AppConfig) cli.ActionFunc {
@@ -126,49 +107,14 @@ func randString(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params stringParams
-
-		if err := params.retriveParams(cCtx); err != nil {
-			return err
-		}
-
-		strReq := stringRequest{
-			APIKey:      cfg.APIKey,
-			Length:      params.Length,
-			Characters:  params.Charset,
-			Number:      params.Number,
-			Replacement: !params.Unique,
-			PregenRand:  cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, strReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    stringResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData := make([]interface{}, 0, len(data))
-		for _, v := range data {
-			outputData = append(outputData, v)
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -179,16 +125,20 @@ func randString(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-type stringRequest struct {
-	APIKey      string              `json:"apiKey"`
-	Length      int                 `json:"length"`
-	Characters  string              `json:"characters"`
-	Number      int                 `json:"n"`
-	Replacement bool                `json:"replacement"`
-	PregenRand  entities.PregenRand `json:"pregeneratedRandomization"`
-}
+// Generate retrieves strings for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().Strings(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
 
-type stringResponseData []string
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, v)
+	}
+
+	return outputData, apiInfo, nil
+}
 
 func charset(s string) string {
 	switch s {
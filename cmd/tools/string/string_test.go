@@ -13,6 +13,7 @@ import (
 	"github.com/tidwall/sjson"
 	"github.com/urfave/cli/v2"
 
+	"github.com/bohdanch-w/rand-api/backend"
 	randstr "github.com/bohdanch-w/rand-api/cmd/tools/string"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
@@ -258,3 +259,34 @@ func TestStringCommandOutputFailed(t *testing.T) {
 	err := app.Run([]string{"main.go", "str"})
 	require.ErrorIs(t, err, entities.Error("test error"))
 }
+
+func TestStringCommand_Seeded(t *testing.T) {
+	// duplicates are dropped keeping first position, alphabet must not depend on map order
+	require.Equal(t, "bac", randstr.ResolveCharset("babca"))
+	require.Empty(t, randstr.ResolveCharset(""), "empty --charset is rejected, not replaced by default")
+
+	for i := 0; i < 5; i++ {
+		ctrl := gomock.NewController(t)
+
+		mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+		mockOutputProcessor.EXPECT().
+			GenerateRandOutput([]any{"7dHr_g1Trhf8", "e7TApdDLERfn"}, gomock.Any()).
+			Return(nil)
+
+		appConfig := &config.AppConfig{
+			Timeout:         time.Second * 5,
+			RandRetriever:   backend.NewSeeded("abc"),
+			OutputProcessor: mockOutputProcessor,
+		}
+
+		app := &cli.App{
+			Name:     "test",
+			Commands: []*cli.Command{randstr.NewStringCommand(appConfig)},
+		}
+
+		err := app.Run([]string{"main.go", "string", "-l", "12", "-N", "2"})
+		require.NoError(t, err)
+
+		ctrl.Finish()
+	}
+}
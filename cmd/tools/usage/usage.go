@@ -0,0 +1,116 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+const (
+	CommandName = "usage"
+	daysParam   = "days"
+	topParam    = "top"
+
+	daysMax = 366
+	topMax  = 100
+)
+
+// nolint: gomnd
+func NewUsageCommand(cfg *config.AppConfig) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "inspect locally recorded api key usage",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "history",
+				Usage: "show daily consumption, top commands and projected quota exhaustion",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    daysParam,
+						Usage:   "number of days to include [1, 366]",
+						Aliases: []string{"d"},
+						Value:   7,
+					},
+					&cli.IntFlag{
+						Name:    topParam,
+						Usage:   "number of top commands and users shown [1, 100]",
+						Aliases: []string{"n"},
+						Value:   5,
+					},
+				},
+				Action: history(cfg),
+			},
+		},
+	}
+}
+
+type historyParams struct {
+	Days int
+	Top  int
+}
+
+func (p *historyParams) retriveParams(ctx *cli.Context) error {
+	p.Days = ctx.Int(daysParam)
+	p.Top = ctx.Int(topParam)
+
+	return p.validate()
+}
+
+func (p *historyParams) validate() error {
+	if err := validation.Validate(
+		p.Days,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(daysMax),
+	); err != nil {
+		return fmt.Errorf("`days` param is invalid: %w", err)
+	}
+
+	if err := validation.Validate(
+		p.Top,
+		validation.Required.Error("must be no less than 1"),
+		validation.Min(1),
+		validation.Max(topMax),
+	); err != nil {
+		return fmt.Errorf("`top` param is invalid: %w", err)
+	}
+
+	return nil
+}
+
+func history(cfg *config.AppConfig) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		const errLedgerDisabled = entities.Error("usage ledger is disabled")
+
+		var params historyParams
+
+		if err := params.retriveParams(cCtx); err != nil {
+			return err
+		}
+
+		if cfg.Ledger == nil {
+			return errLedgerDisabled
+		}
+
+		keyHash := ledger.HashKey(cfg.APIKey)
+
+		entries, err := cfg.Ledger.Entries(func(e ledger.Entry) bool { return e.KeyHash == keyHash })
+		if err != nil {
+			return fmt.Errorf("read usage ledger: %w", err)
+		}
+
+		report := ledger.History(entries, keyHash, time.Now(), params.Days, params.Top)
+
+		if err := cfg.OutputProcessor.GenerateHistoryOutput(report); err != nil {
+			return fmt.Errorf("generate history output: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package usage_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bohdanch-w/rand-api/cmd/tools/usage"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+const apiKey = "c6418ada-7874-4907-9367-f43c446686d3" // nolint: gosec
+
+func TestUsageHistoryCommandSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l := ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl"))
+
+	require.NoError(t, l.Append(ledger.Entry{
+		Timestamp:    time.Now().UTC(),
+		KeyHash:      ledger.HashKey(apiKey),
+		User:         "alice",
+		Command:      "integer",
+		Method:       "generateIntegers",
+		BitsUsed:     100,
+		BitsLeft:     900,
+		RequestsLeft: 9,
+	}))
+	require.NoError(t, l.Append(ledger.Entry{
+		Timestamp: time.Now().UTC(),
+		KeyHash:   ledger.HashKey("another key"),
+		BitsUsed:  100000,
+	}))
+
+	mockOutputProcessor := mock.NewMockOutputProcessor(ctrl)
+
+	mockOutputProcessor.EXPECT().
+		GenerateHistoryOutput(gomock.Any()).
+		Do(func(history entities.UsageHistory) {
+			require.Equal(t, ledger.HashKey(apiKey), history.KeyHash)
+			require.Len(t, history.Days, 3)
+			require.Equal(t, []entities.ConsumerUsage{{Name: "integer", Requests: 1, Bits: 100}}, history.Commands)
+			require.Equal(t, []entities.ConsumerUsage{{Name: "alice", Requests: 1, Bits: 100}}, history.Users)
+			require.Equal(t, uint64(900), history.BitsLeft)
+			require.NotNil(t, history.Exhaustion)
+		}).
+		Return(nil)
+
+	appConfig := &config.AppConfig{
+		APIKey:          apiKey,
+		Timeout:         time.Second * 5,
+		OutputProcessor: mockOutputProcessor,
+		Ledger:          l,
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{usage.NewUsageCommand(appConfig)},
+	}
+
+	err := app.Run([]string{"main.go", "usage", "history", "-d", "3"})
+	require.NoError(t, err)
+}
+
+func TestUsageHistoryCommand_BadParams(t *testing.T) {
+	appConfig := &config.AppConfig{
+		APIKey: apiKey,
+		Ledger: ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl")),
+	}
+
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{usage.NewUsageCommand(appConfig)},
+	}
+
+	testcases := []struct {
+		params        []string
+		expectedError string
+	}{
+		{
+			params:        []string{"-d", "0"},
+			expectedError: "`days` param is invalid: must be no less than 1",
+		},
+		{
+			params:        []string{"-d", "367"},
+			expectedError: "`days` param is invalid: must be no greater than 366",
+		},
+		{
+			params:        []string{"-n", "101"},
+			expectedError: "`top` param is invalid: must be no greater than 100",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := app.Run(append([]string{"main.go", "usage", "history"}, tc.params...))
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestUsageHistoryCommand_LedgerDisabled(t *testing.T) {
+	app := &cli.App{
+		Name:     "test",
+		Commands: []*cli.Command{usage.NewUsageCommand(&config.AppConfig{APIKey: apiKey})},
+	}
+
+	err := app.Run([]string{"main.go", "usage", "history"})
+	require.EqualError(t, err, "usage ledger is disabled")
+}
@@ -2,24 +2,18 @@ package uuid
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/config"
 	"github.com/bohdanch-w/rand-api/entities"
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 )
 
-const (
-	method    = "generateUUIDs"
-	numberMax = 10_000
-)
-
 func NewUUIDCommand(cfg *config.AppConfig) *cli.Command {
+	defaults := DefaultParams()
+
 	return &cli.Command{
 		Name:  "uuid",
 		Usage: "generate random uuid",
@@ -28,34 +22,25 @@ func NewUUIDCommand(cfg *config.AppConfig) *cli.Command {
 				Name:    "number",
 				Usage:   "number of values returned [1, 10000]",
 				Aliases: []string{"N"},
-				Value:   1,
+				Value:   defaults.Number,
 			},
 		},
 		Action: randUUID(cfg),
 	}
 }
 
-type uuidParams struct {
-	Number int
-}
-
-func (p *uuidParams) retriveParams(ctx *cli.Context) error {
-	p.Number = ctx.Int("number")
+type Params = client.UUIDParams
 
-	return p.validate()
+func DefaultParams() Params {
+	return Params{Number: 1}
 }
 
-func (p *uuidParams) validate() error {
-	if err := validation.Validate(
-		p.Number,
-		validation.Required.Error("must be no less than 1"),
-		validation.Min(1),
-		validation.Max(numberMax),
-	); err != nil {
-		return fmt.Errorf("`number` param is invalid: %w", err)
+func retriveParams(ctx *cli.Context) (Params, error) {
+	p := Params{
+		Number: ctx.Int("number"),
 	}
 
-	return nil
+	return p, p.Validate()
 }
 
 func randUUID(cfg *config.AppConfig) cli.ActionFunc {
@@ -63,46 +48,14 @@ func randUUID(cfg *config.AppConfig) cli.ActionFunc {
 		ctx, cancel := context.WithTimeout(cCtx.Context, cfg.Timeout)
 		defer cancel()
 
-		var params uuidParams
-
-		if err := params.retriveParams(cCtx); err != nil {
-			return err
-		}
-
-		uuidReq := uuidRequest{
-			APIKey:     cfg.APIKey,
-			Number:     params.Number,
-			PregenRand: cfg.PregenRand,
-		}
-
-		req, err := cfg.RandRetriever.NewRequest(method, uuidReq)
+		params, err := retriveParams(cCtx)
 		if err != nil {
-			return fmt.Errorf("create request: %w", err)
+			return err
 		}
 
-		result, err := cfg.RandRetriever.ExecuteRequest(ctx, &req)
+		outputData, apiInfo, err := Generate(ctx, cfg, params)
 		if err != nil {
-			return fmt.Errorf("get result: %w", err)
-		}
-
-		var (
-			data    uuidResponseData
-			apiInfo = entities.APIInfo{
-				ID:           req.ID,
-				Timestamp:    time.Time(result.Random.Timestamp),
-				RequestsLeft: result.RequestsLeft,
-				BitsUsed:     result.BitsUsed,
-				BitsLeft:     result.BitsLeft,
-			}
-		)
-
-		if err := json.Unmarshal(result.Random.Data, &data); err != nil {
-			return fmt.Errorf("decode result: %w", err)
-		}
-
-		outputData := make([]interface{}, 0, len(data))
-		for _, v := range data {
-			outputData = append(outputData, v)
+			return err
 		}
 
 		if err := cfg.OutputProcessor.GenerateRandOutput(outputData, apiInfo); err != nil {
@@ -113,10 +66,17 @@ func randUUID(cfg *config.AppConfig) cli.ActionFunc {
 	}
 }
 
-type uuidRequest struct {
-	APIKey     string              `json:"apiKey"`
-	Number     int                 `json:"n"`
-	PregenRand entities.PregenRand `json:"pregeneratedRandomization"`
-}
+// Generate retrieves uuids for already validated params.
+func Generate(ctx context.Context, cfg *config.AppConfig, params Params) ([]interface{}, entities.APIInfo, error) {
+	data, apiInfo, err := cfg.Client().UUIDs(ctx, params)
+	if err != nil {
+		return nil, entities.APIInfo{}, err // nolint: wrapcheck
+	}
 
-type uuidResponseData []uuid.UUID
+	outputData := make([]interface{}, 0, len(data))
+	for _, v := range data {
+		outputData = append(outputData, v)
+	}
+
+	return outputData, apiInfo, nil
+}
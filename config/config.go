@@ -1,9 +1,14 @@
 package config
 
 import (
+	"io"
 	"time"
 
+	"github.com/bohdanch-w/rand-api/client"
 	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/metrics"
+	"github.com/bohdanch-w/rand-api/pool"
 	"github.com/bohdanch-w/rand-api/services"
 )
 
@@ -11,7 +16,23 @@ type AppConfig struct {
 	APIKey     string
 	PregenRand entities.PregenRand
 	Timeout    time.Duration
+	// Signed makes commands supporting it request signed results.
+	Signed bool
 
 	RandRetriever   services.RandRetiever
+	Forwarder       services.RequestForwarder
 	OutputProcessor services.OutputGenerator
+	Output          io.Writer
+	Ledger          *ledger.Ledger
+	Metrics         *metrics.Metrics
+	Pool            *pool.Pool
+	File            FileConfig
+}
+
+// Client returns API client using configured retriever, api key and pregenerated randomization.
+func (cfg *AppConfig) Client() *client.Client {
+	c := client.New(cfg.APIKey, cfg.RandRetriever)
+	c.SetPregenRand(cfg.PregenRand)
+
+	return c
 }
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/gateway"
+	"github.com/bohdanch-w/rand-api/lottery"
+	"github.com/bohdanch-w/rand-api/quota"
+)
+
+const (
+	appDir     = "randapi"
+	configFile = "config.json"
+	auditFile  = "audit.jsonl"
+)
+
+// FileConfig holds settings read from the JSON configuration file.
+type FileConfig struct {
+	AuditLog  string          `json:"auditLog"`
+	Budgets   []budget.Rule   `json:"budgets"`
+	Warnings  quota.Config    `json:"warnings"`
+	Gateway   gateway.Config  `json:"gateway"`
+	Lotteries lottery.Presets `json:"lotteries"`
+}
+
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, appDir), nil
+}
+
+func DefaultFilePath() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, configFile), nil
+}
+
+// LoadFile reads configuration from path. Missing file yields defaults.
+func LoadFile(path string) (FileConfig, error) {
+	var cfg FileConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("read config: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("decode config %s: %w", path, err)
+		}
+	}
+
+	for i, rule := range cfg.Budgets {
+		if err := rule.Validate(); err != nil {
+			return cfg, fmt.Errorf("budgets[%d]: %w", i, err)
+		}
+	}
+
+	if err := cfg.Warnings.Validate(); err != nil {
+		return cfg, fmt.Errorf("warnings: %w", err)
+	}
+
+	if err := cfg.Gateway.Validate(); err != nil {
+		return cfg, fmt.Errorf("gateway: %w", err)
+	}
+
+	if err := cfg.Lotteries.Validate(); err != nil {
+		return cfg, fmt.Errorf("lotteries: %w", err)
+	}
+
+	if cfg.AuditLog == "" && path != "" {
+		cfg.AuditLog = filepath.Join(filepath.Dir(path), auditFile)
+	}
+
+	return cfg, nil
+}
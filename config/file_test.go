@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/config"
+	"github.com/bohdanch-w/rand-api/lottery"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	data := `{
+		"budgets": [
+			{"period": "day", "maxBits": 50000},
+			{"command": "blob", "scope": "key", "period": "hour", "maxRequests": 10}
+		]
+	}`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, filepath.Join(dir, "audit.jsonl"), cfg.AuditLog)
+	require.Equal(t, []budget.Rule{
+		{Period: budget.PeriodDay, MaxBits: 50000},
+		{Command: "blob", Scope: budget.ScopeKey, Period: budget.PeriodHour, MaxRequests: 10},
+	}, cfg.Budgets)
+}
+
+func TestLoadFile_Lotteries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"lotteries": {"euromillions": ["5/50", "2/12"]}}`), 0o600))
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, lottery.Presets{
+		"euromillions": {{Pick: 5, Max: 50}, {Pick: 2, Max: 12}},
+	}, cfg.Lotteries)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"lotteries": {"bad": ["6/5"]}}`), 0o600))
+
+	_, err = config.LoadFile(path)
+	require.ErrorIs(t, err, lottery.ErrInvalidPool)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"lotteries": {"empty": []}}`), 0o600))
+
+	_, err = config.LoadFile(path)
+	require.EqualError(t, err, `lotteries: invalid lottery preset "empty": invalid pool: draw needs 1 to 1000 pools`)
+}
+
+func TestLoadFile_Missing(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := config.LoadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	require.Empty(t, cfg.Budgets)
+	require.Equal(t, filepath.Join(dir, "audit.jsonl"), cfg.AuditLog)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"budgets": [{"period": "week", "maxBits": 1}]}`), 0o600))
+
+	_, err := config.LoadFile(path)
+	require.EqualError(t, err, `budgets[0]: invalid budget rule: period must be one of "hour", "day"`)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0o600))
+
+	_, err = config.LoadFile(path)
+	require.ErrorContains(t, err, "decode config")
+}
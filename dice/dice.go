@@ -0,0 +1,165 @@
+// Package dice parses and rolls tabletop dice notation:
+//
+//	3d6+2     three six-sided dice plus 2
+//	d%        percentile die, same as 1d100
+//	4dF       fudge dice, each -1, 0 or +1
+//	2d20kh1   keep highest die, also k1; kl keeps lowest
+//	4d6dl1    drop lowest die; dh drops highest
+//	3d6!      exploding dice, every maximum adds another die
+//
+// Terms are combined with + and -, e.g. 1d20+1d4-1.
+package dice
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidNotation = entities.Error("invalid dice notation")
+	ErrTooManyDice     = entities.Error("too many dice")
+
+	CountMax = 1_000
+	SidesMax = 1_000_000
+	// ExplosionsMax limits dice added by explosions in a single term.
+	ExplosionsMax = 100
+
+	fudgeSides     = 3
+	percentileSide = 100
+)
+
+// Selection is keep or drop rule applied to rolled dice.
+type Selection string
+
+const (
+	KeepHighest Selection = "kh"
+	KeepLowest  Selection = "kl"
+	DropHighest Selection = "dh"
+	DropLowest  Selection = "dl"
+)
+
+// Dice is NdS part of expression.
+type Dice struct {
+	Count   int
+	Sides   int
+	Fudge   bool
+	Explode bool
+	// Select is applied to all dice of the term, including ones added by explosions.
+	Select Selection
+	N      int
+}
+
+// Term is dice or constant added (Negative subtracted) to expression total.
+type Term struct {
+	Negative bool
+	Dice     *Dice
+	Constant int
+}
+
+type Expression struct {
+	Notation string
+	Terms    []Term
+}
+
+var termRe = regexp.MustCompile(`^([+-]?)(?:(\d*)d(\d+|%|f)(!?)(?:(kh|kl|dh|dl|k)(\d+))?|(\d+))`) // nolint: gochecknoglobals
+
+// Parse parses dice notation, case insensitive.
+func Parse(notation string) (Expression, error) {
+	expr := Expression{Notation: notation}
+	rest := strings.ToLower(strings.ReplaceAll(notation, " ", ""))
+
+	for rest != "" {
+		m := termRe.FindStringSubmatch(rest)
+		if m == nil || (len(expr.Terms) > 0 && m[1] == "") {
+			return Expression{}, fmt.Errorf("%w: %q at %q", ErrInvalidNotation, notation, rest)
+		}
+
+		rest = rest[len(m[0]):]
+
+		term, err := parseTerm(m)
+		if err != nil {
+			return Expression{}, fmt.Errorf("%w: %q: %s", ErrInvalidNotation, notation, err)
+		}
+
+		expr.Terms = append(expr.Terms, term)
+	}
+
+	if len(expr.Terms) == 0 {
+		return Expression{}, fmt.Errorf("%w: empty expression", ErrInvalidNotation)
+	}
+
+	return expr, nil
+}
+
+func parseTerm(m []string) (Term, error) {
+	term := Term{Negative: m[1] == "-"}
+
+	if m[7] != "" {
+		c, err := strconv.Atoi(m[7])
+		if err != nil {
+			return term, fmt.Errorf("constant %s is too big", m[7])
+		}
+
+		term.Constant = c
+
+		return term, nil
+	}
+
+	d := &Dice{Count: 1, Explode: m[4] == "!"}
+
+	if m[2] != "" {
+		d.Count, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "%":
+		d.Sides = percentileSide
+	case "f":
+		d.Fudge, d.Sides = true, fudgeSides
+	default:
+		d.Sides, _ = strconv.Atoi(m[3])
+	}
+
+	if m[5] != "" {
+		d.Select = Selection(m[5])
+		if d.Select == "k" {
+			d.Select = KeepHighest
+		}
+
+		d.N, _ = strconv.Atoi(m[6])
+	}
+
+	return Term{Negative: term.Negative, Dice: d}, d.validate()
+}
+
+func (d *Dice) validate() error {
+	if d.Count < 1 || d.Count > CountMax {
+		return fmt.Errorf("dice count must be in [1, %d]", CountMax)
+	}
+
+	if d.Sides < 1 || d.Sides > SidesMax {
+		return fmt.Errorf("dice sides must be in [1, %d]", SidesMax)
+	}
+
+	if d.Explode && (d.Fudge || d.Sides < 2) {
+		return fmt.Errorf("only dice with 2 or more sides explode")
+	}
+
+	switch d.Select {
+	case "":
+	case KeepHighest, KeepLowest:
+		if d.N < 1 || d.N > d.Count {
+			return fmt.Errorf("kept dice must be in [1, %d]", d.Count)
+		}
+	case DropHighest, DropLowest:
+		if d.N < 1 || d.N >= d.Count {
+			return fmt.Errorf("dropped dice must be in [1, %d]", d.Count-1)
+		}
+	}
+
+	return nil
+}
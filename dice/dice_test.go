@@ -0,0 +1,146 @@
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/dice"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		notation string
+		expected []dice.Term
+	}{
+		{
+			notation: "3d6+2",
+			expected: []dice.Term{
+				{Dice: &dice.Dice{Count: 3, Sides: 6}},
+				{Constant: 2},
+			},
+		},
+		{
+			notation: "d%",
+			expected: []dice.Term{{Dice: &dice.Dice{Count: 1, Sides: 100}}},
+		},
+		{
+			notation: "4DF",
+			expected: []dice.Term{{Dice: &dice.Dice{Count: 4, Sides: 3, Fudge: true}}},
+		},
+		{
+			notation: "2d20k1",
+			expected: []dice.Term{{Dice: &dice.Dice{Count: 2, Sides: 20, Select: dice.KeepHighest, N: 1}}},
+		},
+		{
+			notation: "4d6dl1",
+			expected: []dice.Term{{Dice: &dice.Dice{Count: 4, Sides: 6, Select: dice.DropLowest, N: 1}}},
+		},
+		{
+			notation: "-1 + 1d20 - 1d4!",
+			expected: []dice.Term{
+				{Negative: true, Constant: 1},
+				{Dice: &dice.Dice{Count: 1, Sides: 20}},
+				{Negative: true, Dice: &dice.Dice{Count: 1, Sides: 4, Explode: true}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		expr, err := dice.Parse(tc.notation)
+		require.NoError(t, err, tc.notation)
+		require.Equal(t, tc.notation, expr.Notation)
+		require.Equal(t, tc.expected, expr.Terms, tc.notation)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, notation := range []string{
+		"", "d", "3x6", "3d6+", "3d6 2d6", "0d6", "1001d6", "1d0", "1d1000001",
+		"2d20kh3", "2d6dl2", "4dF!", "1d1!", "3d6kh",
+	} {
+		_, err := dice.Parse(notation)
+		require.ErrorIs(t, err, dice.ErrInvalidNotation, notation)
+	}
+}
+
+// queue draws values in order and records requested sides of every round.
+type queue struct {
+	values []int
+	rounds [][]int
+}
+
+func (q *queue) draw(sides []int) ([]int, error) {
+	q.rounds = append(q.rounds, sides)
+
+	values := q.values[:len(sides)]
+	q.values = q.values[len(sides):]
+
+	return values, nil
+}
+
+func TestRoll(t *testing.T) {
+	var exprs []dice.Expression
+
+	for _, notation := range []string{"3d6+2", "2d20kh1", "4d6dl1", "4dF", "2d6!-1", "3d8dh1"} {
+		expr, err := dice.Parse(notation)
+		require.NoError(t, err)
+
+		exprs = append(exprs, expr)
+	}
+
+	q := &queue{values: []int{
+		4, 2, 6, // 3d6
+		3, 17, // 2d20
+		5, 4, 1, 3, // 4d6
+		3, 2, 1, 1, // 4dF
+		6, 6, // 2d6!
+		8, 8, 2, // 3d8
+		6, 5, // explosions of 2d6!
+		1, // explosion of explosion
+	}}
+
+	results, err := dice.Roll(exprs, q.draw)
+	require.NoError(t, err)
+	require.Empty(t, q.values)
+	require.Equal(t, [][]int{
+		{6, 6, 6, 20, 20, 6, 6, 6, 6, 3, 3, 3, 3, 6, 6, 8, 8, 8},
+		{6, 6},
+		{6},
+	}, q.rounds)
+
+	rolls := make([]string, 0, len(results))
+	totals := make([]int, 0, len(results))
+
+	for _, r := range results {
+		rolls = append(rolls, r.String())
+		totals = append(totals, r.Total)
+	}
+
+	require.Equal(t, []string{
+		"3d6+2=[4,2,6]+2=14",
+		"2d20kh1=[(3),17]=17",
+		"4d6dl1=[5,4,(1),3]=12",
+		"4dF=[+,0,-,-]=-1",
+		"2d6!-1=[6!,6!,6!,5,1]-1=23",
+		"3d8dh1=[8,(8),2]=10",
+	}, rolls)
+	require.Equal(t, []int{14, 17, 12, -1, 23, 10}, totals)
+}
+
+func TestRoll_ExplosionsLimit(t *testing.T) {
+	expr, err := dice.Parse("1d2!")
+	require.NoError(t, err)
+
+	results, err := dice.Roll([]dice.Expression{expr}, func(sides []int) ([]int, error) {
+		values := make([]int, len(sides))
+		for i := range values {
+			values[i] = 2
+		}
+
+		return values, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results[0].Terms[0].Rolls, dice.ExplosionsMax+1)
+	require.Equal(t, 2*(dice.ExplosionsMax+1), results[0].Total)
+}
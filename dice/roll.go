@@ -0,0 +1,244 @@
+package dice
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RoundMax is the largest number of dice drawn at once.
+const RoundMax = 10_000
+
+// Draw rolls a die for every element of sides and returns values in [1, sides[i]].
+type Draw func(sides []int) ([]int, error)
+
+// Die is a rolled die. Fudge dice have values -1, 0 and 1.
+type Die struct {
+	Value int
+	// Exploded die rolled maximum and added another die.
+	Exploded bool
+	Dropped  bool
+}
+
+type TermResult struct {
+	Term
+	Rolls []Die
+	Total int
+}
+
+type Result struct {
+	Expression
+	Terms []TermResult
+	Total int
+}
+
+// Roll rolls expressions drawing dice in rounds: all dice in the first round,
+// then dice added by explosions of the previous round until none explode.
+func Roll(exprs []Expression, draw Draw) ([]Result, error) {
+	var (
+		results = make([]Result, len(exprs))
+		pending []*TermResult
+		counts  []int
+	)
+
+	for i, expr := range exprs {
+		results[i] = Result{Expression: expr, Terms: make([]TermResult, len(expr.Terms))}
+
+		for j, term := range expr.Terms {
+			results[i].Terms[j].Term = term
+
+			if term.Dice != nil {
+				pending = append(pending, &results[i].Terms[j])
+				counts = append(counts, term.Dice.Count)
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		var err error
+
+		if pending, counts, err = roll(pending, counts, draw); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range results {
+		for j := range results[i].Terms {
+			results[i].Total += results[i].Terms[j].total()
+		}
+	}
+
+	return results, nil
+}
+
+// roll draws counts[i] dice for pending[i] and returns terms with explosions to roll next.
+func roll(pending []*TermResult, counts []int, draw Draw) ([]*TermResult, []int, error) {
+	var sides []int
+
+	for i, t := range pending {
+		for range counts[i] {
+			sides = append(sides, t.Term.Dice.Sides)
+		}
+	}
+
+	if len(sides) > RoundMax {
+		return nil, nil, fmt.Errorf("%w: %d dice at once, at most %d", ErrTooManyDice, len(sides), RoundMax)
+	}
+
+	values, err := draw(sides)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(values) != len(sides) {
+		return nil, nil, fmt.Errorf("drew %d dice instead of %d", len(values), len(sides))
+	}
+
+	var (
+		next       []*TermResult
+		nextCounts []int
+	)
+
+	for i, t := range pending {
+		prior, explosions := t.explosions(), 0
+
+		for _, v := range values[:counts[i]] {
+			die := Die{Value: v}
+
+			if t.Term.Dice.Fudge {
+				die.Value = v - 2 // nolint: gomnd
+			}
+
+			if t.Term.Dice.Explode && v == t.Term.Dice.Sides && prior+explosions < ExplosionsMax {
+				die.Exploded = true
+				explosions++
+			}
+
+			t.Rolls = append(t.Rolls, die)
+		}
+
+		values = values[counts[i]:]
+
+		if explosions > 0 {
+			next = append(next, t)
+			nextCounts = append(nextCounts, explosions)
+		}
+	}
+
+	return next, nextCounts, nil
+}
+
+func (t *TermResult) explosions() int {
+	n := 0
+
+	for _, d := range t.Rolls {
+		if d.Exploded {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (t *TermResult) total() int {
+	if t.Term.Dice == nil {
+		t.Total = t.Constant
+	} else {
+		t.selectDice()
+
+		for _, d := range t.Rolls {
+			if !d.Dropped {
+				t.Total += d.Value
+			}
+		}
+	}
+
+	if t.Negative {
+		return -t.Total
+	}
+
+	return t.Total
+}
+
+// selectDice marks dice dropped by keep or drop rule.
+func (t *TermResult) selectDice() {
+	order := make([]int, len(t.Rolls))
+	for i := range order {
+		order[i] = i
+	}
+
+	// ascending by value, earlier die first among equal
+	sort.SliceStable(order, func(a, b int) bool { return t.Rolls[order[a]].Value < t.Rolls[order[b]].Value })
+
+	var drop []int
+
+	switch n := t.Term.Dice.N; t.Term.Dice.Select {
+	case KeepHighest:
+		drop = order[:len(order)-min(n, len(order))]
+	case KeepLowest:
+		drop = order[min(n, len(order)):]
+	case DropHighest:
+		drop = order[len(order)-n:]
+	case DropLowest:
+		drop = order[:n]
+	}
+
+	for _, i := range drop {
+		t.Rolls[i].Dropped = true
+	}
+}
+
+// String is roll with every die, e.g. 4d6dl1=[5,4,(1),3]=12. Dropped dice
+// are in parentheses, exploded ones are marked with !.
+func (r Result) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(r.Notation)
+	sb.WriteString("=")
+
+	for i, t := range r.Terms {
+		switch {
+		case t.Negative:
+			sb.WriteString("-")
+		case i > 0:
+			sb.WriteString("+")
+		}
+
+		if t.Term.Dice == nil {
+			sb.WriteString(strconv.Itoa(t.Constant))
+
+			continue
+		}
+
+		dice := make([]string, 0, len(t.Rolls))
+
+		for _, d := range t.Rolls {
+			dice = append(dice, d.format(t.Term.Dice.Fudge))
+		}
+
+		sb.WriteString("[" + strings.Join(dice, ",") + "]")
+	}
+
+	sb.WriteString("=" + strconv.Itoa(r.Total))
+
+	return sb.String()
+}
+
+func (d Die) format(fudge bool) string {
+	s := strconv.Itoa(d.Value)
+
+	if fudge {
+		s = [...]string{"-", "0", "+"}[d.Value+1]
+	}
+
+	if d.Exploded {
+		s += "!"
+	}
+
+	if d.Dropped {
+		s = "(" + s + ")"
+	}
+
+	return s
+}
@@ -12,4 +12,21 @@ type APIInfo struct {
 	BitsUsed     uint64
 	BitsLeft     uint64
 	RequestsLeft uint64
+	Source       string
+	// SerialNumber and Signature are set for signed results.
+	SerialNumber int64
+	Signature    string
+}
+
+// Merge combines info of a command made of several calls: ID of the first
+// call is kept, bits used are summed and the rest is taken from next.
+func (info APIInfo) Merge(next APIInfo) APIInfo {
+	if info == (APIInfo{}) {
+		return next
+	}
+
+	next.ID = info.ID
+	next.BitsUsed += info.BitsUsed
+
+	return next
 }
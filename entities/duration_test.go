@@ -0,0 +1,27 @@
+package entities_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+func TestDurationMarshalling(t *testing.T) {
+	var v struct {
+		D entities.Duration `json:"d"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"d":"1h30m"}`), &v))
+	require.Equal(t, entities.Duration(90*time.Minute), v.D)
+
+	bb, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"d":"1h30m0s"}`, string(bb))
+
+	require.Error(t, json.Unmarshal([]byte(`{"d":"soon"}`), &v))
+	require.Error(t, json.Unmarshal([]byte(`{"d":15}`), &v))
+}
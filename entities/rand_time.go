@@ -22,3 +22,9 @@ func (c *RandTime) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+func (c RandTime) MarshalJSON() ([]byte, error) {
+	const format = "2006-01-02 15:04:05Z"
+
+	return []byte(`"` + time.Time(c).UTC().Format(format) + `"`), nil
+}
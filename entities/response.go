@@ -19,11 +19,17 @@ type RandResponseResult struct {
 	BitsLeft      uint64     `json:"bitsLeft"`
 	RequestsLeft  uint64     `json:"requestsLeft"`
 	AdvisoryDelay uint64     `json:"advisoryDelay"`
+	// Signature of Random, set by generateSigned* methods.
+	Signature string `json:"signature,omitempty"`
+	// Source is name of the backend that produced values.
+	Source string `json:"-"`
 }
 
 type RandomData struct {
 	Data      json.RawMessage `json:"data"`
 	Timestamp RandTime        `json:"completionTime"`
+	// SerialNumber identifies signed results, zero for unsigned ones.
+	SerialNumber int64 `json:"serialNumber,omitempty"`
 }
 
 type ErrorResponse struct {
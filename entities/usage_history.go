@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+type UsageHistory struct {
+	KeyHash      string
+	From         time.Time
+	To           time.Time
+	Days         []ConsumerUsage
+	Commands     []ConsumerUsage
+	Users        []ConsumerUsage
+	BitsLeft     uint64
+	RequestsLeft uint64
+	Exhaustion   *time.Time
+}
+
+type ConsumerUsage struct {
+	Name     string
+	Requests uint64
+	Bits     uint64
+}
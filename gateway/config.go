@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const ErrInvalidClient = entities.Error("invalid gateway client")
+
+// Client is an internal consumer allowed to use the gateway.
+type Client struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	// Methods the client may invoke. Empty allows every method.
+	Methods []string `json:"methods,omitempty"`
+	// Budgets are checked against the client's consumption recorded in usage ledger.
+	Budgets []budget.Rule `json:"budgets,omitempty"`
+}
+
+func (c Client) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidClient)
+	}
+
+	if c.Token == "" {
+		return fmt.Errorf("%w: token is required", ErrInvalidClient)
+	}
+
+	for i, rule := range c.Budgets {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("budgets[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c Client) allows(method string) bool {
+	if len(c.Methods) == 0 {
+		return true
+	}
+
+	for _, m := range c.Methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Config struct {
+	Clients []Client `json:"clients"`
+}
+
+func (cfg Config) Validate() error {
+	var (
+		names  = make(map[string]struct{}, len(cfg.Clients))
+		tokens = make(map[string]struct{}, len(cfg.Clients))
+	)
+
+	for i, client := range cfg.Clients {
+		if err := client.Validate(); err != nil {
+			return fmt.Errorf("clients[%d]: %w", i, err)
+		}
+
+		if _, ok := names[client.Name]; ok {
+			return fmt.Errorf("clients[%d]: %w: duplicate name %q", i, ErrInvalidClient, client.Name)
+		}
+
+		if _, ok := tokens[client.Token]; ok {
+			return fmt.Errorf("clients[%d]: %w: duplicate token", i, ErrInvalidClient)
+		}
+
+		names[client.Name] = struct{}{}
+		tokens[client.Token] = struct{}{}
+	}
+
+	return nil
+}
+
+// HasBudgets reports whether any client requires usage ledger.
+func (cfg Config) HasBudgets() bool {
+	for _, client := range cfg.Clients {
+		if len(client.Budgets) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,240 @@
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+const (
+	// LedgerCommand is the command recorded in usage ledger for gateway requests.
+	LedgerCommand = "gateway"
+	// InvokePath is the path random.org clients post requests to.
+	InvokePath = "/json-rpc/4/invoke"
+
+	jsonRPCVersion = "2.0"
+	maxBodySize    = 1 << 20
+)
+
+// JSON-RPC and random.org error codes, so existing clients handle them as usual.
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotAllowed = -32601
+	codeInternalError    = -32603
+	codeUpstreamError    = -32000
+	codeUnknownKey       = 400
+	codeRequestsExceeded = 402
+	codeBitsExceeded     = 403
+)
+
+func New(cfg Config, forwarder services.RequestForwarder, apiKey string, l *ledger.Ledger, timeout time.Duration) *Gateway {
+	return &Gateway{
+		clients:   cfg.Clients,
+		forwarder: forwarder,
+		apiKey:    apiKey,
+		ledger:    l,
+		timeout:   timeout,
+		now:       time.Now,
+	}
+}
+
+// Gateway accepts random.org compatible JSON-RPC requests authorized by
+// client token in place of api key and forwards them with the real key.
+type Gateway struct {
+	clients   []Client
+	forwarder services.RequestForwarder
+	apiKey    string
+	ledger    *ledger.Ledger
+	timeout   time.Duration
+	now       func() time.Time
+}
+
+type rpcRequest struct {
+	JsonrpcVersion string          `json:"jsonrpc"`
+	Method         string          `json:"method"`
+	Params         json.RawMessage `json:"params"`
+	ID             json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []interface{} `json:"data"`
+}
+
+type rpcErrorResponse struct {
+	JsonrpcVersion string          `json:"jsonrpc"`
+	Error          rpcError        `json:"error"`
+	ID             json.RawMessage `json:"id"`
+}
+
+func (gw *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("POST "+InvokePath, gw)
+	mux.Handle("POST /{$}", gw)
+
+	return mux
+}
+
+func (gw *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, nil, codeParseError, "Parse error")
+
+		return
+	}
+
+	var req rpcRequest
+
+	if err := json.Unmarshal(payload, &req); err != nil {
+		writeError(w, nil, codeParseError, "Parse error")
+
+		return
+	}
+
+	if req.JsonrpcVersion != jsonRPCVersion || req.Method == "" || !gjson.ValidBytes(req.Params) {
+		writeError(w, req.ID, codeInvalidRequest, "Invalid Request")
+
+		return
+	}
+
+	client, ok := gw.authenticate(gjson.GetBytes(req.Params, "apiKey").String())
+	if !ok {
+		writeError(w, req.ID, codeUnknownKey, "The API key you specified does not exist")
+
+		return
+	}
+
+	if !client.allows(req.Method) {
+		writeError(w, req.ID, codeMethodNotAllowed, "Method not allowed for this client")
+
+		return
+	}
+
+	if code, msg := gw.checkBudgets(client); code != 0 {
+		writeError(w, req.ID, code, msg)
+
+		return
+	}
+
+	payload, err = sjson.SetBytes(payload, "params.apiKey", gw.apiKey)
+	if err != nil {
+		writeError(w, req.ID, codeInternalError, "Internal error")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), gw.timeout)
+	defer cancel()
+
+	data, err := gw.forwarder.Forward(ctx, payload)
+	if err != nil {
+		log.Printf("ERROR: forward %s of %s: %s\n", req.Method, client.Name, err)
+		writeError(w, req.ID, codeUpstreamError, "random.org request failed")
+
+		return
+	}
+
+	gw.record(client, req, data)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(data)
+}
+
+func (gw *Gateway) authenticate(token string) (Client, bool) {
+	if token == "" {
+		return Client{}, false
+	}
+
+	for _, c := range gw.clients {
+		if subtle.ConstantTimeCompare([]byte(c.Token), []byte(token)) == 1 {
+			return c, true
+		}
+	}
+
+	return Client{}, false
+}
+
+// checkBudgets returns random.org allowance error if client exhausted one of its budgets.
+func (gw *Gateway) checkBudgets(client Client) (int, string) {
+	if len(client.Budgets) == 0 || gw.ledger == nil {
+		return 0, ""
+	}
+
+	keyHash := ledger.HashKey(gw.apiKey)
+
+	entries, err := gw.ledger.Entries(func(e ledger.Entry) bool { return e.KeyHash == keyHash })
+	if err != nil {
+		log.Printf("ERROR: read usage ledger: %s\n", err)
+
+		return codeInternalError, "Internal error"
+	}
+
+	violation := budget.Check(client.Budgets, entries, client.Name, LedgerCommand, gw.now().UTC())
+	if violation == nil {
+		return 0, ""
+	}
+
+	msg := fmt.Sprintf("%s: %s", budget.ErrBudgetExceeded, violation)
+
+	if violation.Rule.MaxBits > 0 && violation.Bits >= violation.Rule.MaxBits {
+		return codeBitsExceeded, msg
+	}
+
+	return codeRequestsExceeded, msg
+}
+
+func (gw *Gateway) record(client Client, req rpcRequest, data []byte) {
+	if gw.ledger == nil {
+		return
+	}
+
+	result := gjson.GetBytes(data, "result")
+	if !result.Exists() {
+		return
+	}
+
+	entry := ledger.Entry{
+		Timestamp:    gw.now().UTC(),
+		KeyHash:      ledger.HashKey(gw.apiKey),
+		User:         client.Name,
+		Command:      LedgerCommand,
+		Method:       req.Method,
+		ParamsDigest: ledger.DigestParams(req.Params),
+		BitsUsed:     result.Get("bitsUsed").Uint(),
+		BitsLeft:     result.Get("bitsLeft").Uint(),
+		RequestsLeft: result.Get("requestsLeft").Uint(),
+	}
+
+	if err := gw.ledger.Append(entry); err != nil {
+		log.Printf("WARN: usage ledger: %s\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, id json.RawMessage, code int, msg string) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	_ = json.NewEncoder(w).Encode(rpcErrorResponse{
+		JsonrpcVersion: jsonRPCVersion,
+		Error:          rpcError{Code: code, Message: msg, Data: []interface{}{}},
+		ID:             id,
+	})
+}
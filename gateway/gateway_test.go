@@ -0,0 +1,199 @@
+package gateway_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/bohdanch-w/rand-api/budget"
+	"github.com/bohdanch-w/rand-api/gateway"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const (
+	apiKey   = "c6418ada-7874-4907-9367-f43c446686d3" // nolint: gosec
+	response = `{"jsonrpc":"2.0","result":{"random":{"data":[4],"completionTime":"2022-08-25 12:15:44Z"},` +
+		`"bitsUsed":3,"bitsLeft":249997,"requestsLeft":999,"advisoryDelay":0},"id":42}`
+)
+
+func newGateway(t *testing.T, cfg gateway.Config, l *ledger.Ledger) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var forwarded []string
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		forwarded = append(forwarded, string(body))
+
+		_, err = w.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(upstream.Close)
+
+	retriever := randapi.NewRandomOrgRetriever(upstream.URL, http.DefaultClient, false)
+	gw := gateway.New(cfg, retriever, apiKey, l, time.Second*5)
+
+	ts := httptest.NewServer(gw.Handler())
+	t.Cleanup(ts.Close)
+
+	return ts, &forwarded
+}
+
+func invoke(t *testing.T, url, body string) string {
+	t.Helper()
+
+	resp, err := http.Post(url+gateway.InvokePath, "application/json", bytes.NewBufferString(body)) // nolint: noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestGateway_Forward(t *testing.T) {
+	l := ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl"))
+	cfg := gateway.Config{Clients: []gateway.Client{{Name: "ci", Token: "ci-token"}}}
+
+	ts, forwarded := newGateway(t, cfg, l)
+
+	req := `{"jsonrpc":"2.0","method":"generateIntegers","params":{"apiKey":"ci-token","n":1,"min":1,"max":6},"id":42}`
+
+	require.Equal(t, response, invoke(t, ts.URL, req))
+	require.Equal(t, []string{
+		`{"jsonrpc":"2.0","method":"generateIntegers","params":{"apiKey":"` + apiKey + `","n":1,"min":1,"max":6},"id":42}`,
+	}, *forwarded)
+
+	entries, err := l.Entries(nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, ledger.HashKey(apiKey), entries[0].KeyHash)
+	require.Equal(t, "ci", entries[0].User)
+	require.Equal(t, gateway.LedgerCommand, entries[0].Command)
+	require.Equal(t, "generateIntegers", entries[0].Method)
+	require.Equal(t, uint64(3), entries[0].BitsUsed)
+	require.Equal(t, uint64(999), entries[0].RequestsLeft)
+}
+
+func TestGateway_UpstreamFailed(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer upstream.Close()
+
+	cfg := gateway.Config{Clients: []gateway.Client{{Name: "ci", Token: "ci-token"}}}
+	retriever := randapi.NewRandomOrgRetriever(upstream.URL, http.DefaultClient, false)
+	gw := gateway.New(cfg, retriever, apiKey, ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl")), time.Second*5)
+
+	ts := httptest.NewServer(gw.Handler())
+	defer ts.Close()
+
+	resp := invoke(t, ts.URL, `{"jsonrpc":"2.0","method":"generateIntegers","params":{"apiKey":"ci-token"},"id":7}`)
+
+	require.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32000,"message":"random.org request failed","data":[]},"id":7}`, resp)
+}
+
+func TestGateway_Rejected(t *testing.T) {
+	l := ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl"))
+	cfg := gateway.Config{Clients: []gateway.Client{
+		{Name: "ci", Token: "ci-token", Methods: []string{"generateIntegers"}},
+		{
+			Name:    "limited",
+			Token:   "limited-token",
+			Budgets: []budget.Rule{{Period: budget.PeriodDay, MaxRequests: 1}},
+		},
+	}}
+
+	ts, forwarded := newGateway(t, cfg, l)
+
+	testcases := []struct {
+		name         string
+		request      string
+		expectedCode int64
+		expectedID   string
+	}{
+		{name: "malformed", request: `{`, expectedCode: -32700, expectedID: "null"},
+		{name: "not json-rpc 2.0", request: `{"method":"getUsage","params":{},"id":1}`, expectedCode: -32600, expectedID: "1"},
+		{
+			name:         "unknown token",
+			request:      `{"jsonrpc":"2.0","method":"generateIntegers","params":{"apiKey":"` + apiKey + `"},"id":"a"}`,
+			expectedCode: 400,
+			expectedID:   `"a"`,
+		},
+		{
+			name:         "method not allowed",
+			request:      `{"jsonrpc":"2.0","method":"generateBlobs","params":{"apiKey":"ci-token"},"id":2}`,
+			expectedCode: -32601,
+			expectedID:   "2",
+		},
+	}
+
+	for _, tc := range testcases {
+		resp := invoke(t, ts.URL, tc.request)
+
+		require.Equal(t, tc.expectedCode, gjson.Get(resp, "error.code").Int(), tc.name)
+		require.Equal(t, tc.expectedID, gjson.Get(resp, "id").Raw, tc.name)
+	}
+
+	require.Empty(t, *forwarded)
+
+	req := `{"jsonrpc":"2.0","method":"generateIntegers","params":{"apiKey":"limited-token"},"id":42}`
+
+	require.Equal(t, response, invoke(t, ts.URL, req))
+
+	resp := invoke(t, ts.URL, req)
+	require.Equal(t, int64(402), gjson.Get(resp, "error.code").Int())
+	require.Equal(t, "budget exceeded: user used 1/1 requests per day", gjson.Get(resp, "error.message").String())
+	require.Len(t, *forwarded, 1)
+}
+
+func TestConfigValidate(t *testing.T) {
+	testcases := []struct {
+		cfg         gateway.Config
+		expectedErr string
+	}{
+		{
+			cfg:         gateway.Config{Clients: []gateway.Client{{Token: "t"}}},
+			expectedErr: "clients[0]: invalid gateway client: name is required",
+		},
+		{
+			cfg:         gateway.Config{Clients: []gateway.Client{{Name: "a"}}},
+			expectedErr: "clients[0]: invalid gateway client: token is required",
+		},
+		{
+			cfg:         gateway.Config{Clients: []gateway.Client{{Name: "a", Token: "t"}, {Name: "b", Token: "t"}}},
+			expectedErr: "clients[1]: invalid gateway client: duplicate token",
+		},
+		{
+			cfg: gateway.Config{Clients: []gateway.Client{
+				{Name: "a", Token: "t", Budgets: []budget.Rule{{Period: budget.PeriodDay}}},
+			}},
+			expectedErr: "clients[0]: budgets[0]: invalid budget rule: either maxBits or maxRequests is required",
+		},
+		{cfg: gateway.Config{Clients: []gateway.Client{{Name: "a", Token: "t"}, {Name: "b", Token: "u"}}}},
+	}
+
+	for _, tc := range testcases {
+		err := tc.cfg.Validate()
+
+		if tc.expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tc.expectedErr)
+		}
+	}
+}
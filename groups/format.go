@@ -0,0 +1,97 @@
+package groups
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Format renders groups as text lines, JSON array or CSV with group,member rows.
+// Groups are numbered from 1.
+func Format(groups [][]Member, format string) (string, error) {
+	switch format {
+	case FormatText:
+		return formatText(groups), nil
+	case FormatJSON:
+		return formatJSON(groups)
+	case FormatCSV:
+		return formatCSV(groups)
+	default:
+		return "", fmt.Errorf("%w: format must be one of %s, %s, %s", ErrInvalidGroups, FormatText, FormatJSON, FormatCSV)
+	}
+}
+
+func formatText(groups [][]Member) string {
+	lines := make([]string, 0, len(groups))
+
+	for i, g := range groups {
+		lines = append(lines, fmt.Sprintf("Group %d: %s", i+1, strings.Join(names(g), ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type jsonGroup struct {
+	Group   int      `json:"group"`
+	Members []string `json:"members"`
+}
+
+func formatJSON(groups [][]Member) (string, error) {
+	res := make([]jsonGroup, 0, len(groups))
+
+	for i, g := range groups {
+		res = append(res, jsonGroup{Group: i + 1, Members: names(g)})
+	}
+
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode groups: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func formatCSV(groups [][]Member) (string, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"group", "member"}); err != nil {
+		return "", fmt.Errorf("encode groups: %w", err)
+	}
+
+	for i, g := range groups {
+		for _, m := range g {
+			if err := w.Write([]string{strconv.Itoa(i + 1), m.Name}); err != nil {
+				return "", fmt.Errorf("encode groups: %w", err)
+			}
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("encode groups: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func names(members []Member) []string {
+	res := make([]string, 0, len(members))
+
+	for _, m := range members {
+		res = append(res, m.Name)
+	}
+
+	return res
+}
@@ -0,0 +1,127 @@
+// Package groups splits members into balanced random groups. Members of
+// every stratum are shuffled and dealt to groups in turn, continuing from
+// group the previous stratum stopped at, so every stratum is spread across
+// groups. Order of groups in the deal is random too, so no group is more
+// likely to get the extra member of a stratum.
+package groups
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidMember = entities.Error("invalid member")
+	ErrInvalidGroups = entities.Error("invalid groups")
+
+	// MembersMax and StrataMax keep permutations within a single call:
+	// 10,000 integers in 1,000 sequences.
+	MembersMax = 5_000
+	StrataMax  = 999
+)
+
+// Member belongs to Stratum, members with the same stratum are spread across groups.
+type Member struct {
+	Name    string
+	Stratum string
+}
+
+// ReadMembers reads CSV rows with member name in the first column. Stratify
+// selects stratum column: 1-based number or name of the column in header row.
+// Without stratify every row is a member.
+func ReadMembers(r io.Reader, stratify string) ([]Member, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	column, err := stratumColumn(reader, stratify)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []Member
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return members, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		m := Member{Name: strings.TrimSpace(record[0])}
+
+		if column >= 0 && column < len(record) {
+			m.Stratum = strings.TrimSpace(record[column])
+		}
+
+		members = append(members, m)
+	}
+}
+
+// stratumColumn returns 0-based index of stratum column, -1 without stratify.
+// Header row is read when column is given by name.
+func stratumColumn(reader *csv.Reader, stratify string) (int, error) {
+	if stratify == "" {
+		return -1, nil
+	}
+
+	if n, err := strconv.Atoi(stratify); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("%w: stratify column %d, columns start at 1", ErrInvalidGroups, n)
+		}
+
+		return n - 1, nil
+	}
+
+	header, err := reader.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("read csv: %w", err)
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), stratify) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: no %q column in header", ErrInvalidGroups, stratify)
+}
+
+// CountForSize returns number of groups with at most size members.
+func CountForSize(members, size int) int {
+	if size < 1 {
+		return 0
+	}
+
+	return (members + size - 1) / size
+}
+
+// Pin puts member Name to group Group, 1-based.
+type Pin struct {
+	Name  string
+	Group int
+}
+
+// ParsePin parses NAME:GROUP.
+func ParsePin(s string) (Pin, error) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return Pin{}, fmt.Errorf("%w: pin %q must be NAME:GROUP", ErrInvalidGroups, s)
+	}
+
+	group, err := strconv.Atoi(s[i+1:])
+	if err != nil || strings.TrimSpace(s[:i]) == "" {
+		return Pin{}, fmt.Errorf("%w: pin %q must be NAME:GROUP", ErrInvalidGroups, s)
+	}
+
+	return Pin{Name: strings.TrimSpace(s[:i]), Group: group}, nil
+}
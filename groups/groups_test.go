@@ -0,0 +1,186 @@
+package groups_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/groups"
+)
+
+func TestReadMembers(t *testing.T) {
+	data := "name,level\nann,senior\nbob\ncid, junior\n"
+
+	members, err := groups.ReadMembers(strings.NewReader(data), "Level")
+	require.NoError(t, err)
+	require.Equal(t, []groups.Member{
+		{Name: "ann", Stratum: "senior"},
+		{Name: "bob"},
+		{Name: "cid", Stratum: "junior"},
+	}, members)
+
+	members, err = groups.ReadMembers(strings.NewReader(data), "2")
+	require.NoError(t, err)
+	require.Len(t, members, 4)
+	require.Equal(t, groups.Member{Name: "name", Stratum: "level"}, members[0])
+
+	members, err = groups.ReadMembers(strings.NewReader(data), "")
+	require.NoError(t, err)
+	require.Equal(t, groups.Member{Name: "ann"}, members[1])
+
+	_, err = groups.ReadMembers(strings.NewReader(data), "team")
+	require.EqualError(t, err, `invalid groups: no "team" column in header`)
+
+	_, err = groups.ReadMembers(strings.NewReader(data), "0")
+	require.EqualError(t, err, "invalid groups: stratify column 0, columns start at 1")
+}
+
+func TestParsePin(t *testing.T) {
+	pin, err := groups.ParsePin("Ann Lee:2")
+	require.NoError(t, err)
+	require.Equal(t, groups.Pin{Name: "Ann Lee", Group: 2}, pin)
+
+	for _, s := range []string{"ann", "ann:", ":2", "ann:x"} {
+		_, err := groups.ParsePin(s)
+		require.ErrorIs(t, err, groups.ErrInvalidGroups, s)
+	}
+}
+
+func TestCountForSize(t *testing.T) {
+	require.Equal(t, 3, groups.CountForSize(10, 4))
+	require.Equal(t, 2, groups.CountForSize(8, 4))
+	require.Equal(t, 0, groups.CountForSize(8, 0))
+}
+
+func members(names string) []groups.Member {
+	var res []groups.Member
+
+	for _, m := range strings.Fields(names) {
+		name, stratum, _ := strings.Cut(m, "/")
+		res = append(res, groups.Member{Name: name, Stratum: stratum})
+	}
+
+	return res
+}
+
+func TestNewPlan_Invalid(t *testing.T) {
+	testCases := []struct {
+		members       string
+		count         int
+		pins          []groups.Pin
+		expectedError string
+	}{
+		{members: "", count: 1, expectedError: "invalid groups: 0 members, must be in [1, 5000]"},
+		{members: "a b", count: 3, expectedError: "invalid groups: 3 groups of 2 members"},
+		{members: "a b", count: 0, expectedError: "invalid groups: 0 groups of 2 members"},
+		{members: "a A", count: 1, expectedError: `invalid member: "A" is listed twice`},
+		{
+			members: "a b", count: 2, pins: []groups.Pin{{Name: "c", Group: 1}},
+			expectedError: `invalid member: unknown pinned member "c"`,
+		},
+		{
+			members: "a b", count: 2, pins: []groups.Pin{{Name: "a", Group: 3}},
+			expectedError: `invalid groups: "a" pinned to group 3 of 2`,
+		},
+		{
+			members: "a b", count: 2, pins: []groups.Pin{{Name: "a", Group: 1}, {Name: "A", Group: 2}},
+			expectedError: `invalid groups: "A" is pinned twice`,
+		},
+		{
+			members: "a b c d", count: 2, pins: []groups.Pin{{Name: "a", Group: 1}, {Name: "b", Group: 1}, {Name: "c", Group: 1}},
+			expectedError: "invalid groups: more than 2 members pinned to group 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := groups.NewPlan(members(tc.members), tc.count, tc.pins)
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func names(split [][]groups.Member) [][]string {
+	res := make([][]string, 0, len(split))
+
+	for _, g := range split {
+		var n []string
+		for _, m := range g {
+			n = append(n, m.Name)
+		}
+
+		res = append(res, n)
+	}
+
+	return res
+}
+
+func TestPlan_Split(t *testing.T) {
+	plan, err := groups.NewPlan(members("s1/s j1/j s2/s j2/j j3/j s3/s m1/m j4/j"), 3,
+		[]groups.Pin{{Name: "m1", Group: 3}})
+	require.NoError(t, err)
+
+	// group order, seniors, juniors
+	require.Equal(t, []int{3, 3, 4}, plan.Lengths())
+
+	split, err := plan.Split(func(lengths []int) ([][]int, error) {
+		require.Equal(t, []int{3, 3, 4}, lengths)
+
+		return [][]int{{2, 0, 1}, {1, 2, 0}, {3, 0, 2, 1}}, nil
+	})
+	require.NoError(t, err)
+
+	// deal order is group 3, 1, 2: seniors s2, s3, s1, then juniors j4, j1, j3, j2
+	// with group 3 full after m1, s2, j4
+	require.Equal(t, [][]string{
+		{"s3", "j1", "j2"},
+		{"s1", "j3"},
+		{"m1", "s2", "j4"},
+	}, names(split))
+}
+
+func TestPlan_SplitTrivial(t *testing.T) {
+	plan, err := groups.NewPlan(members("a/x b/y"), 1, nil)
+	require.NoError(t, err)
+
+	split, err := plan.Split(func([]int) ([][]int, error) {
+		require.Fail(t, "no permutations are needed")
+
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"a", "b"}}, names(split))
+}
+
+func TestPlan_SplitInvalidPermutation(t *testing.T) {
+	plan, err := groups.NewPlan(members("a b c"), 2, nil)
+	require.NoError(t, err)
+
+	_, err = plan.Split(func([]int) ([][]int, error) {
+		return [][]int{{0, 1}, {0, 0, 1}}, nil
+	})
+	require.EqualError(t, err, "invalid permutation: [0 0 1] of 3 elements")
+
+	_, err = plan.Split(func([]int) ([][]int, error) {
+		return [][]int{{0, 1}}, nil
+	})
+	require.EqualError(t, err, "invalid permutation: 1 permutations instead of 2")
+}
+
+func TestFormat(t *testing.T) {
+	split := [][]groups.Member{{{Name: "ann"}, {Name: "bob, jr"}}, {{Name: "cid"}}}
+
+	text, err := groups.Format(split, groups.FormatText)
+	require.NoError(t, err)
+	require.Equal(t, "Group 1: ann, bob, jr\nGroup 2: cid", text)
+
+	js, err := groups.Format(split, groups.FormatJSON)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"group":1,"members":["ann","bob, jr"]},{"group":2,"members":["cid"]}]`, js)
+
+	csv, err := groups.Format(split, groups.FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "group,member\n1,ann\n1,\"bob, jr\"\n2,cid", csv)
+
+	_, err = groups.Format(split, "xml")
+	require.EqualError(t, err, "invalid groups: format must be one of text, json, csv")
+}
@@ -0,0 +1,211 @@
+package groups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const ErrInvalidPermutation = entities.Error("invalid permutation")
+
+// Perms returns a uniformly random permutation of [0, n) for every n of lengths.
+type Perms func(lengths []int) ([][]int, error)
+
+// Plan is members to split into Count groups of at most capacity members.
+type Plan struct {
+	Members  []Member
+	Count    int
+	capacity int
+	// pinned is 0-based group of every member, -1 if member is dealt.
+	pinned []int
+	// strata are indices of dealt members by stratum in order of appearance.
+	strata [][]int
+}
+
+func NewPlan(members []Member, count int, pins []Pin) (*Plan, error) {
+	n := len(members)
+
+	switch {
+	case n == 0 || n > MembersMax:
+		return nil, fmt.Errorf("%w: %d members, must be in [1, %d]", ErrInvalidGroups, n, MembersMax)
+	case count < 1 || count > n:
+		return nil, fmt.Errorf("%w: %d groups of %d members", ErrInvalidGroups, count, n)
+	}
+
+	p := &Plan{
+		Members:  members,
+		Count:    count,
+		capacity: (n + count - 1) / count,
+		pinned:   make([]int, n),
+	}
+
+	index := make(map[string]int, n)
+
+	for i, m := range members {
+		key := strings.ToLower(m.Name)
+
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty name", ErrInvalidMember)
+		}
+
+		if _, ok := index[key]; ok {
+			return nil, fmt.Errorf("%w: %q is listed twice", ErrInvalidMember, m.Name)
+		}
+
+		index[key] = i
+		p.pinned[i] = -1
+	}
+
+	pinnedTo := make([]int, count)
+
+	for _, pin := range pins {
+		i, ok := index[strings.ToLower(pin.Name)]
+
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: unknown pinned member %q", ErrInvalidMember, pin.Name)
+		case pin.Group < 1 || pin.Group > count:
+			return nil, fmt.Errorf("%w: %q pinned to group %d of %d", ErrInvalidGroups, pin.Name, pin.Group, count)
+		case p.pinned[i] >= 0:
+			return nil, fmt.Errorf("%w: %q is pinned twice", ErrInvalidGroups, pin.Name)
+		}
+
+		p.pinned[i] = pin.Group - 1
+
+		if pinnedTo[pin.Group-1]++; pinnedTo[pin.Group-1] > p.capacity {
+			return nil, fmt.Errorf("%w: more than %d members pinned to group %d", ErrInvalidGroups, p.capacity, pin.Group)
+		}
+	}
+
+	strata := make(map[string]int)
+
+	for i, m := range members {
+		if p.pinned[i] >= 0 {
+			continue
+		}
+
+		s, ok := strata[m.Stratum]
+		if !ok {
+			s = len(p.strata)
+			strata[m.Stratum] = s
+			p.strata = append(p.strata, nil)
+		}
+
+		p.strata[s] = append(p.strata[s], i)
+	}
+
+	if len(p.strata) > StrataMax {
+		return nil, fmt.Errorf("%w: %d strata, at most %d", ErrInvalidGroups, len(p.strata), StrataMax)
+	}
+
+	return p, nil
+}
+
+// Lengths returns lengths of permutations the split needs: order of groups,
+// then members of every stratum.
+func (p *Plan) Lengths() []int {
+	lengths := []int{p.Count}
+
+	for _, stratum := range p.strata {
+		lengths = append(lengths, len(stratum))
+	}
+
+	return lengths
+}
+
+// Split deals members to groups. Permutations of less than 2 elements aren't requested.
+func (p *Plan) Split(perms Perms) ([][]Member, error) {
+	drawn, err := p.permutations(perms)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		order  = drawn[0]
+		groups = make([][]Member, p.Count)
+		pos    = 0
+	)
+
+	for i, g := range p.pinned {
+		if g >= 0 {
+			groups[g] = append(groups[g], p.Members[i])
+		}
+	}
+
+	for s, stratum := range p.strata {
+		for _, k := range drawn[s+1] {
+			for len(groups[order[pos%p.Count]]) >= p.capacity {
+				pos++
+			}
+
+			g := order[pos%p.Count]
+			groups[g] = append(groups[g], p.Members[stratum[k]])
+			pos++
+		}
+	}
+
+	return groups, nil
+}
+
+// permutations returns permutation for every length, drawing only ones with 2 or more elements.
+func (p *Plan) permutations(perms Perms) ([][]int, error) {
+	var (
+		lengths   = p.Lengths()
+		res       = make([][]int, len(lengths))
+		requested []int
+		positions []int
+	)
+
+	for i, l := range lengths {
+		if l < 2 {
+			res[i] = make([]int, l)
+
+			continue
+		}
+
+		requested = append(requested, l)
+		positions = append(positions, i)
+	}
+
+	if len(requested) == 0 {
+		return res, nil
+	}
+
+	drawn, err := perms(requested)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drawn) != len(requested) {
+		return nil, fmt.Errorf("%w: %d permutations instead of %d", ErrInvalidPermutation, len(drawn), len(requested))
+	}
+
+	for k, perm := range drawn {
+		if !isPermutation(perm, requested[k]) {
+			return nil, fmt.Errorf("%w: %v of %d elements", ErrInvalidPermutation, perm, requested[k])
+		}
+
+		res[positions[k]] = perm
+	}
+
+	return res, nil
+}
+
+func isPermutation(perm []int, n int) bool {
+	if len(perm) != n {
+		return false
+	}
+
+	seen := make([]bool, n)
+
+	for _, v := range perm {
+		if v < 0 || v >= n || seen[v] {
+			return false
+		}
+
+		seen[v] = true
+	}
+
+	return true
+}
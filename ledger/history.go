@@ -0,0 +1,150 @@
+package ledger
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	dayFormat = "2006-01-02"
+	day       = 24 * time.Hour
+)
+
+// History aggregates entries of a single key made in the last `days` days before now.
+func History(entries []Entry, keyHash string, now time.Time, days, top int) entities.UsageHistory {
+	now = now.UTC()
+
+	var (
+		to       = now.Truncate(day).Add(day)
+		from     = to.Add(-time.Duration(days) * day)
+		daily    = make(map[string]*entities.ConsumerUsage, days)
+		commands = make(map[string]*entities.ConsumerUsage)
+		users    = make(map[string]*entities.ConsumerUsage)
+		latest   *Entry
+		history  = entities.UsageHistory{
+			KeyHash: keyHash,
+			From:    from,
+			To:      to,
+		}
+	)
+
+	for d := from; d.Before(to); d = d.Add(day) {
+		name := d.Format(dayFormat)
+		history.Days = append(history.Days, entities.ConsumerUsage{Name: name})
+	}
+
+	for i := range history.Days {
+		daily[history.Days[i].Name] = &history.Days[i]
+	}
+
+	for i, e := range entries {
+		if e.KeyHash != keyHash {
+			continue
+		}
+
+		if latest == nil || !e.Timestamp.Before(latest.Timestamp) {
+			latest = &entries[i]
+		}
+
+		if e.Timestamp.Before(from) || !e.Timestamp.Before(to) {
+			continue
+		}
+
+		account(daily, e.Timestamp.UTC().Format(dayFormat), e)
+		account(commands, nonEmpty(e.Command, e.Method), e)
+		account(users, nonEmpty(e.User, "unknown"), e)
+	}
+
+	history.Commands = topConsumers(commands, top)
+	history.Users = topConsumers(users, top)
+
+	if latest != nil {
+		history.BitsLeft = latest.BitsLeft
+		history.RequestsLeft = latest.RequestsLeft
+		history.Exhaustion = projectExhaustion(history, latest.Timestamp)
+	}
+
+	return history
+}
+
+func account(usage map[string]*entities.ConsumerUsage, name string, e Entry) {
+	u, ok := usage[name]
+	if !ok {
+		u = &entities.ConsumerUsage{Name: name}
+		usage[name] = u
+	}
+
+	u.Requests++
+	u.Bits += e.BitsUsed
+}
+
+func topConsumers(usage map[string]*entities.ConsumerUsage, top int) []entities.ConsumerUsage {
+	result := make([]entities.ConsumerUsage, 0, len(usage))
+
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Bits != result[j].Bits {
+			return result[i].Bits > result[j].Bits
+		}
+
+		if result[i].Requests != result[j].Requests {
+			return result[i].Requests > result[j].Requests
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+
+	return result
+}
+
+// projectExhaustion extrapolates average daily consumption over the history window
+// and returns the earliest date bits or requests are expected to run out.
+func projectExhaustion(history entities.UsageHistory, since time.Time) *time.Time {
+	var bits, requests uint64
+
+	for _, d := range history.Days {
+		bits += d.Bits
+		requests += d.Requests
+	}
+
+	if len(history.Days) == 0 || (bits == 0 && requests == 0) {
+		return nil
+	}
+
+	var (
+		days     = float64(len(history.Days))
+		daysLeft = -1.0
+	)
+
+	if bits > 0 {
+		daysLeft = float64(history.BitsLeft) / (float64(bits) / days)
+	}
+
+	if requests > 0 {
+		left := float64(history.RequestsLeft) / (float64(requests) / days)
+		if daysLeft < 0 || left < daysLeft {
+			daysLeft = left
+		}
+	}
+
+	exhaustion := since.Add(time.Duration(daysLeft * float64(day))).UTC()
+
+	return &exhaustion
+}
+
+func nonEmpty(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+
+	return s
+}
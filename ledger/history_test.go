@@ -0,0 +1,70 @@
+package ledger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+func TestHistory(t *testing.T) {
+	now := time.Date(2022, 8, 25, 18, 0, 0, 0, time.UTC)
+
+	entry := func(daysAgo int, user, command string, bits, bitsLeft, requestsLeft uint64) ledger.Entry {
+		return ledger.Entry{
+			Timestamp:    now.AddDate(0, 0, -daysAgo),
+			KeyHash:      "key",
+			User:         user,
+			Command:      command,
+			Method:       "generateIntegers",
+			BitsUsed:     bits,
+			BitsLeft:     bitsLeft,
+			RequestsLeft: requestsLeft,
+		}
+	}
+
+	entries := []ledger.Entry{
+		entry(10, "alice", "blob", 5000, 10000, 100),
+		entry(2, "alice", "blob", 1000, 9000, 99),
+		entry(1, "bob", "integer", 100, 8900, 98),
+		entry(0, "bob", "integer", 100, 8800, 97),
+		entry(0, "alice", "coin", 200, 8600, 96),
+		{Timestamp: now, KeyHash: "other", BitsUsed: 99999},
+	}
+
+	history := ledger.History(entries, "key", now, 3, 2)
+
+	require.Equal(t, time.Date(2022, 8, 23, 0, 0, 0, 0, time.UTC), history.From)
+	require.Equal(t, time.Date(2022, 8, 26, 0, 0, 0, 0, time.UTC), history.To)
+	require.Equal(t, []entities.ConsumerUsage{
+		{Name: "2022-08-23", Requests: 1, Bits: 1000},
+		{Name: "2022-08-24", Requests: 1, Bits: 100},
+		{Name: "2022-08-25", Requests: 2, Bits: 300},
+	}, history.Days)
+	require.Equal(t, []entities.ConsumerUsage{
+		{Name: "blob", Requests: 1, Bits: 1000},
+		{Name: "integer", Requests: 2, Bits: 200},
+	}, history.Commands)
+	require.Equal(t, []entities.ConsumerUsage{
+		{Name: "alice", Requests: 2, Bits: 1200},
+		{Name: "bob", Requests: 2, Bits: 200},
+	}, history.Users)
+	require.Equal(t, uint64(8600), history.BitsLeft)
+	require.Equal(t, uint64(96), history.RequestsLeft)
+
+	// 1400 bits over 3 days leaves ~18.4 days of bits, 4 requests leaves 72 days of requests.
+	require.NotNil(t, history.Exhaustion)
+	require.Equal(t, time.Date(2022, 9, 13, 0, 0, 0, 0, time.UTC), history.Exhaustion.Truncate(24*time.Hour))
+}
+
+func TestHistory_NoEntries(t *testing.T) {
+	history := ledger.History(nil, "key", time.Now(), 2, 5)
+
+	require.Len(t, history.Days, 2)
+	require.Empty(t, history.Commands)
+	require.Empty(t, history.Users)
+	require.Nil(t, history.Exhaustion)
+}
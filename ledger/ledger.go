@@ -0,0 +1,168 @@
+package ledger
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tidwall/sjson"
+)
+
+const (
+	appDir      = "randapi"
+	ledgerFile  = "ledger.jsonl"
+	digestLen   = 16
+	filePerm    = 0o600
+	dirPerm     = 0o700
+	maxLineSize = 1 << 20
+)
+
+type Entry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	KeyHash      string    `json:"key"`
+	User         string    `json:"user"`
+	Command      string    `json:"command"`
+	Method       string    `json:"method"`
+	ParamsDigest string    `json:"params"`
+	BitsUsed     uint64    `json:"bitsUsed"`
+	BitsLeft     uint64    `json:"bitsLeft"`
+	RequestsLeft uint64    `json:"requestsLeft"`
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, appDir, ledgerFile), nil
+}
+
+func New(path string) *Ledger {
+	return &Ledger{path: path}
+}
+
+// Ledger is an append-only JSONL log of executed requests.
+type Ledger struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (l *Ledger) Path() string {
+	return l.path
+}
+
+func (l *Ledger) Append(entry Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), dirPerm); err != nil {
+		return fmt.Errorf("create ledger dir: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerm)
+	if err != nil {
+		return fmt.Errorf("open ledger: %w", err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write ledger: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns all entries accepted by filter. Nil filter accepts everything.
+func (l *Ledger) Entries(filter func(Entry) bool) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("open ledger: %w", err)
+	}
+
+	defer f.Close()
+
+	var (
+		entries []Entry
+		scanner = bufio.NewScanner(f)
+		line    int
+	)
+
+	scanner.Buffer(nil, maxLineSize)
+
+	for scanner.Scan() {
+		line++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decode ledger line %d: %w", line, err)
+		}
+
+		if filter == nil || filter(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ledger: %w", err)
+	}
+
+	return entries, nil
+}
+
+func HashKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(sum[:])[:digestLen]
+}
+
+// DigestParams hashes request parameters with the api key stripped,
+// so identical draws made with different keys share a digest.
+func DigestParams(params json.RawMessage) string {
+	stripped, err := sjson.DeleteBytes(params, "apiKey")
+	if err != nil {
+		stripped = params
+	}
+
+	sum := sha256.Sum256(stripped)
+
+	return hex.EncodeToString(sum[:])[:digestLen]
+}
+
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return os.Getenv("USERNAME")
+}
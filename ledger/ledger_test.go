@@ -0,0 +1,70 @@
+package ledger_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+func TestLedgerAppendEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ledger.jsonl")
+	l := ledger.New(path)
+
+	entries, err := l.Entries(nil)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	first := ledger.Entry{
+		Timestamp:    time.Date(2022, 8, 25, 12, 0, 0, 0, time.UTC),
+		KeyHash:      "aaaa",
+		User:         "alice",
+		Command:      "integer",
+		Method:       "generateIntegers",
+		ParamsDigest: "0011",
+		BitsUsed:     20,
+		BitsLeft:     1000,
+		RequestsLeft: 10,
+	}
+	second := first
+	second.KeyHash = "bbbb"
+	second.User = "bob"
+
+	require.NoError(t, l.Append(first))
+	require.NoError(t, l.Append(second))
+
+	entries, err = l.Entries(nil)
+	require.NoError(t, err)
+	require.Equal(t, []ledger.Entry{first, second}, entries)
+
+	entries, err = l.Entries(func(e ledger.Entry) bool { return e.KeyHash == "bbbb" })
+	require.NoError(t, err)
+	require.Equal(t, []ledger.Entry{second}, entries)
+}
+
+func TestLedgerCorruptedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{}\nnot json\n"), 0o600))
+
+	_, err := ledger.New(path).Entries(nil)
+	require.ErrorContains(t, err, "decode ledger line 2")
+}
+
+func TestDigests(t *testing.T) {
+	require.Len(t, ledger.HashKey("c6418ada-7874-4907-9367-f43c446686d3"), 16)
+	require.NotEqual(t, ledger.HashKey("a"), ledger.HashKey("b"))
+
+	require.Equal(t,
+		ledger.DigestParams(json.RawMessage(`{"apiKey":"a","n":1}`)),
+		ledger.DigestParams(json.RawMessage(`{"apiKey":"b","n":1}`)),
+	)
+	require.NotEqual(t,
+		ledger.DigestParams(json.RawMessage(`{"apiKey":"a","n":1}`)),
+		ledger.DigestParams(json.RawMessage(`{"apiKey":"a","n":2}`)),
+	)
+}
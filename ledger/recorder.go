@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+var _ services.RandRetiever = (*Recorder)(nil)
+
+func NewRecorder(next services.RandRetiever, l *Ledger, user, command string) *Recorder {
+	return &Recorder{
+		next:    next,
+		ledger:  l,
+		user:    user,
+		command: command,
+		now:     time.Now,
+	}
+}
+
+// Recorder is a RandRetiever that writes every successful request to the ledger.
+type Recorder struct {
+	next    services.RandRetiever
+	ledger  *Ledger
+	user    string
+	command string
+	now     func() time.Time
+}
+
+func (svc *Recorder) NewRequest(method string, params services.RandParameters) (entities.RandomRequest, error) {
+	return svc.next.NewRequest(method, params) // nolint: wrapcheck
+}
+
+func (svc *Recorder) ExecuteRequest(
+	ctx context.Context,
+	randReq *entities.RandomRequest,
+) (entities.RandResponseResult, error) {
+	result, err := svc.next.ExecuteRequest(ctx, randReq)
+	if err != nil {
+		return result, err // nolint: wrapcheck
+	}
+
+	entry := Entry{
+		Timestamp:    svc.now().UTC(),
+		KeyHash:      HashKey(gjson.GetBytes(randReq.Params, "apiKey").String()),
+		User:         svc.user,
+		Command:      svc.command,
+		Method:       randReq.Method,
+		ParamsDigest: DigestParams(randReq.Params),
+		BitsUsed:     result.BitsUsed,
+		BitsLeft:     result.BitsLeft,
+		RequestsLeft: result.RequestsLeft,
+	}
+
+	if err := svc.ledger.Append(entry); err != nil {
+		log.Printf("WARN: usage ledger: %s\n", err)
+	}
+
+	return result, nil
+}
+
+func (svc *Recorder) GetUsage(ctx context.Context, apiKey string) (entities.UsageStatus, error) {
+	return svc.next.GetUsage(ctx, apiKey) // nolint: wrapcheck
+}
@@ -0,0 +1,64 @@
+package ledger_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+	"github.com/bohdanch-w/rand-api/pkg/testutils"
+	"github.com/bohdanch-w/rand-api/services/mock"
+)
+
+func TestRecorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := entities.RandomRequest{
+		ID:             uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"),
+		JsonrpcVersion: "2.0",
+		Method:         "generateIntegers",
+		Params:         json.RawMessage(`{"apiKey":"c6418ada-7874-4907-9367-f43c446686d3","n":3}`),
+	}
+
+	mockRandRetriever := mock.NewMockRandRetiever(ctrl)
+
+	gomock.InOrder(
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(testutils.TestRandResult(t, "[1, 2, 3]"), nil),
+		mockRandRetriever.EXPECT().
+			ExecuteRequest(gomock.Any(), &req).
+			Return(entities.RandResponseResult{}, entities.Error("test error")),
+	)
+
+	l := ledger.New(filepath.Join(t.TempDir(), "ledger.jsonl"))
+	svc := ledger.NewRecorder(mockRandRetriever, l, "alice", "integer")
+
+	result, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+	require.Equal(t, testutils.TestRandResult(t, "[1, 2, 3]"), result)
+
+	_, err = svc.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, entities.Error("test error"))
+
+	entries, err := l.Entries(nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entry := entries[0]
+	require.Equal(t, ledger.HashKey("c6418ada-7874-4907-9367-f43c446686d3"), entry.KeyHash)
+	require.Equal(t, "alice", entry.User)
+	require.Equal(t, "integer", entry.Command)
+	require.Equal(t, "generateIntegers", entry.Method)
+	require.Equal(t, ledger.DigestParams(req.Params), entry.ParamsDigest)
+	require.Equal(t, uint64(150), entry.BitsUsed)
+	require.Equal(t, uint64(1477), entry.BitsLeft)
+	require.Equal(t, uint64(233), entry.RequestsLeft)
+}
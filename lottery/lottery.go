@@ -0,0 +1,144 @@
+// Package lottery describes pools of a lottery draw: K/N draws K distinct
+// numbers of 1..N, e.g. 5/50 and 2/12 of EuroMillions. Presets are named
+// sets of pools read from configuration file:
+//
+//	"lotteries": {"euromillions": ["5/50", "2/12"]}
+package lottery
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidPool   = entities.Error("invalid pool")
+	ErrInvalidPreset = entities.Error("invalid lottery preset")
+
+	// MaxMax is the largest number of a pool.
+	MaxMax = 1_000_000_000
+	// PicksMax is the largest number of values drawn from all pools at once.
+	PicksMax = 10_000
+	PoolsMax = 1_000
+
+	poolSep = "/"
+)
+
+// Pool draws Pick distinct numbers of 1..Max.
+type Pool struct {
+	Pick int
+	Max  int
+}
+
+// ParsePool parses K/N notation.
+func ParsePool(s string) (Pool, error) {
+	pick, max, ok := strings.Cut(strings.TrimSpace(s), poolSep)
+	if !ok {
+		return Pool{}, fmt.Errorf("%w: %q must be K/N", ErrInvalidPool, s)
+	}
+
+	var (
+		p       Pool
+		pickErr error
+		maxErr  error
+	)
+
+	p.Pick, pickErr = strconv.Atoi(pick)
+	p.Max, maxErr = strconv.Atoi(max)
+
+	if pickErr != nil || maxErr != nil {
+		return Pool{}, fmt.Errorf("%w: %q must be K/N", ErrInvalidPool, s)
+	}
+
+	return p, p.Validate()
+}
+
+func (p Pool) Validate() error {
+	if p.Max < 2 || p.Max > MaxMax {
+		return fmt.Errorf("%w: %s, N must be in [2, %d]", ErrInvalidPool, p, MaxMax)
+	}
+
+	if p.Pick < 1 || p.Pick > p.Max || p.Pick > PicksMax {
+		return fmt.Errorf("%w: %s, K must be in [1, N]", ErrInvalidPool, p)
+	}
+
+	return nil
+}
+
+func (p Pool) String() string {
+	return fmt.Sprintf("%d%s%d", p.Pick, poolSep, p.Max)
+}
+
+func (p Pool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String()) // nolint: wrapcheck
+}
+
+func (p *Pool) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s must be K/N string", ErrInvalidPool, data)
+	}
+
+	pool, err := ParsePool(s)
+	if err != nil {
+		return err
+	}
+
+	*p = pool
+
+	return nil
+}
+
+// ValidatePools checks pools drawn together.
+func ValidatePools(pools []Pool) error {
+	if len(pools) == 0 || len(pools) > PoolsMax {
+		return fmt.Errorf("%w: draw needs 1 to %d pools", ErrInvalidPool, PoolsMax)
+	}
+
+	picks := 0
+
+	for _, p := range pools {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+
+		picks += p.Pick
+	}
+
+	if picks > PicksMax {
+		return fmt.Errorf("%w: %d numbers drawn, at most %d", ErrInvalidPool, picks, PicksMax)
+	}
+
+	return nil
+}
+
+// Presets are pools of lotteries by name.
+type Presets map[string][]Pool
+
+func (p Presets) Validate() error {
+	for name, pools := range p {
+		if err := ValidatePools(pools); err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidPreset, name, err)
+		}
+	}
+
+	return nil
+}
+
+// Names returns sorted preset names.
+func (p Presets) Names() []string {
+	names := make([]string, 0, len(p))
+
+	for name := range p {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
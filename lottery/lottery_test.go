@@ -0,0 +1,45 @@
+package lottery_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/lottery"
+)
+
+func TestParsePool(t *testing.T) {
+	p, err := lottery.ParsePool(" 5/50 ")
+	require.NoError(t, err)
+	require.Equal(t, lottery.Pool{Pick: 5, Max: 50}, p)
+	require.Equal(t, "5/50", p.String())
+
+	for _, s := range []string{"", "5", "5/", "/50", "a/50", "0/50", "6/5", "1/1", "1/1000000001", "10001/20000"} {
+		_, err := lottery.ParsePool(s)
+		require.ErrorIs(t, err, lottery.ErrInvalidPool, s)
+	}
+}
+
+func TestPool_JSON(t *testing.T) {
+	var presets lottery.Presets
+
+	require.NoError(t, json.Unmarshal([]byte(`{"euromillions": ["5/50", "2/12"]}`), &presets))
+	require.Equal(t, lottery.Presets{"euromillions": {{Pick: 5, Max: 50}, {Pick: 2, Max: 12}}}, presets)
+
+	data, err := json.Marshal(presets)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"euromillions": ["5/50", "2/12"]}`, string(data))
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"x": [5]}`), &presets), lottery.ErrInvalidPool)
+}
+
+func TestValidatePools(t *testing.T) {
+	require.NoError(t, lottery.ValidatePools([]lottery.Pool{{Pick: 5, Max: 50}, {Pick: 2, Max: 12}}))
+
+	err := lottery.ValidatePools(nil)
+	require.EqualError(t, err, "invalid pool: draw needs 1 to 1000 pools")
+
+	err = lottery.ValidatePools([]lottery.Pool{{Pick: 6_000, Max: 10_000}, {Pick: 6_000, Max: 10_000}})
+	require.EqualError(t, err, "invalid pool: 12000 numbers drawn, at most 10000")
+}
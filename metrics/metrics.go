@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const (
+	namespace = "randapi"
+	// Path the metrics are served on in long-running modes.
+	Path = "/metrics"
+)
+
+var _ randapi.Observer = (*Metrics)(nil)
+
+// Metrics is a randapi.Observer recording random.org calls into its own registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	bitsUsed      *prometheus.HistogramVec
+	bitsLeft      prometheus.Gauge
+	requestsLeft  prometheus.Gauge
+	advisoryWaits prometheus.Histogram
+}
+
+// nolint: gomnd
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Calls to random.org by method and outcome.",
+		}, []string{"method", "outcome"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of random.org calls.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"method"}),
+		bitsUsed: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_bits",
+			Help:      "Bits consumed by successful random.org calls.",
+			Buckets:   prometheus.ExponentialBuckets(8, 4, 10),
+		}, []string{"method"}),
+		bitsLeft: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "bits_left",
+			Help:      "Bits left for the api key as last reported by random.org.",
+		}),
+		requestsLeft: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "requests_left",
+			Help:      "Requests left for the api key as last reported by random.org.",
+		}),
+		advisoryWaits: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "advisory_delay_wait_seconds",
+			Help:      "Time calls waited for advisory delay requested by random.org.",
+			Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5},
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.bitsUsed,
+		m.bitsLeft,
+		m.requestsLeft,
+		m.advisoryWaits,
+	)
+
+	return m
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) ObserveRequest(method string, outcome randapi.Outcome, duration time.Duration) {
+	m.requests.WithLabelValues(method, string(outcome)).Inc()
+	m.duration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveBitsUsed(method string, bits uint64) {
+	m.bitsUsed.WithLabelValues(method).Observe(float64(bits))
+}
+
+func (m *Metrics) ObserveRemaining(bitsLeft, requestsLeft uint64) {
+	m.bitsLeft.Set(float64(bitsLeft))
+	m.requestsLeft.Set(float64(requestsLeft))
+}
+
+func (m *Metrics) ObserveAdvisoryWait(d time.Duration) {
+	m.advisoryWaits.Observe(d.Seconds())
+}
+
+// Expose serves metrics on Path and everything else with next.
+// Nil Metrics returns next unchanged.
+func (m *Metrics) Expose(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET "+Path, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/", next)
+
+	return mux
+}
+
+// WriteFile writes metrics in text format for node_exporter textfile collector.
+func (m *Metrics) WriteFile(path string) error {
+	if err := prometheus.WriteToTextfile(path, m.registry); err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/metrics"
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const response = `{"jsonrpc":"2.0","result":{"random":{"data":[4],"completionTime":"2022-08-25 12:15:44Z"},` +
+	`"bitsUsed":3,"bitsLeft":249997,"requestsLeft":999,"advisoryDelay":20},"id":""}`
+
+func newRetriever(t *testing.T, m *metrics.Metrics) *randapi.RandomOrgRetriever {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		resp, err := sjson.Set(response, "id", gjson.GetBytes(body, "id").String())
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(resp))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+
+	svc := randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false)
+	svc.SetObserver(m)
+
+	return svc
+}
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	svc := newRetriever(t, m)
+
+	for i := 0; i < 2; i++ {
+		req, err := svc.NewRequest("generateIntegers", map[string]int{"n": 1})
+		require.NoError(t, err)
+
+		_, err = svc.ExecuteRequest(context.Background(), &req)
+		require.NoError(t, err)
+	}
+
+	expected := `
+# HELP randapi_requests_total Calls to random.org by method and outcome.
+# TYPE randapi_requests_total counter
+randapi_requests_total{method="generateIntegers",outcome="success"} 2
+# HELP randapi_bits_left Bits left for the api key as last reported by random.org.
+# TYPE randapi_bits_left gauge
+randapi_bits_left 249997
+# HELP randapi_requests_left Requests left for the api key as last reported by random.org.
+# TYPE randapi_requests_left gauge
+randapi_requests_left 999
+`
+
+	require.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"randapi_requests_total", "randapi_bits_left", "randapi_requests_left",
+	))
+
+	// second call waited for advisory delay of the first one
+	count, err := testutil.GatherAndCount(m.Registry(), "randapi_advisory_delay_wait_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestMetrics_Outcome(t *testing.T) {
+	m := metrics.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":401,"message":"not running"},"id":null}`))
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	svc := randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false)
+	svc.SetObserver(m)
+
+	_, err := svc.ExecuteRequest(context.Background(), &entities.RandomRequest{Method: "generateBlobs"})
+	require.ErrorIs(t, err, randapi.ErrErrorInResponse)
+
+	expected := `
+# HELP randapi_requests_total Calls to random.org by method and outcome.
+# TYPE randapi_requests_total counter
+randapi_requests_total{method="generateBlobs",outcome="rpc_error"} 1
+`
+
+	require.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "randapi_requests_total"))
+
+	count, err := testutil.GatherAndCount(m.Registry(), "randapi_request_bits")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestMetrics_WriteFileAndExpose(t *testing.T) {
+	m := metrics.New()
+	m.ObserveRequest("getUsage", randapi.OutcomeSuccess, 0)
+
+	path := filepath.Join(t.TempDir(), "randapi.prom")
+	require.NoError(t, m.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `randapi_requests_total{method="getUsage",outcome="success"} 1`)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+
+	ts := httptest.NewServer(m.Expose(next))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + metrics.Path) // nolint: noctx
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Contains(t, string(body), `randapi_requests_total{method="getUsage",outcome="success"} 1`)
+
+	resp, err = http.Get(ts.URL + "/v1/usage") // nolint: noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusTeapot, resp.StatusCode)
+}
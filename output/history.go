@@ -0,0 +1,57 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const dateFormat = "02-01-2006"
+
+func (svc *GeneratorImplementation) GenerateHistoryOutput(history entities.UsageHistory) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Usage history for API key #%s (%s - %s):\n",
+		history.KeyHash,
+		history.From.Format(dateFormat),
+		history.To.AddDate(0, 0, -1).Format(dateFormat),
+	)
+
+	sb.WriteString("  Daily consumption:\n")
+	writeConsumers(&sb, history.Days)
+
+	sb.WriteString("  Top commands:\n")
+	writeConsumers(&sb, history.Commands)
+
+	sb.WriteString("  Top users:\n")
+	writeConsumers(&sb, history.Users)
+
+	fmt.Fprintf(&sb, "  RequestsLeft:  %d\n", history.RequestsLeft)
+	fmt.Fprintf(&sb, "  BitsLeft:      %d\n", history.BitsLeft)
+
+	exhaustion := "not projected"
+	if history.Exhaustion != nil {
+		exhaustion = history.Exhaustion.Format(dateFormat)
+	}
+
+	fmt.Fprintf(&sb, "  Exhaustion:    %s\n", exhaustion)
+
+	if _, err := fmt.Fprint(svc.writer, sb.String()); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	return nil
+}
+
+func writeConsumers(sb *strings.Builder, usage []entities.ConsumerUsage) {
+	if len(usage) == 0 {
+		sb.WriteString("    none\n")
+
+		return
+	}
+
+	for _, u := range usage {
+		fmt.Fprintf(sb, "    %-16s requests: %-6d bits: %d\n", u.Name, u.Requests, u.Bits)
+	}
+}
@@ -0,0 +1,61 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/output"
+)
+
+func TestGenerateHistoryOutput(t *testing.T) {
+	exhaustion := time.Date(2022, 9, 12, 6, 0, 0, 0, time.UTC)
+
+	history := entities.UsageHistory{
+		KeyHash: "0123456789abcdef",
+		From:    time.Date(2022, 8, 24, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2022, 8, 26, 0, 0, 0, 0, time.UTC),
+		Days: []entities.ConsumerUsage{
+			{Name: "2022-08-24", Requests: 1, Bits: 100},
+			{Name: "2022-08-25", Requests: 2, Bits: 300},
+		},
+		Commands:     []entities.ConsumerUsage{{Name: "integer", Requests: 3, Bits: 400}},
+		BitsLeft:     8600,
+		RequestsLeft: 96,
+		Exhaustion:   &exhaustion,
+	}
+
+	expected := `
+Usage history for API key #0123456789abcdef (24-08-2022 - 25-08-2022):
+  Daily consumption:
+    2022-08-24       requests: 1      bits: 100
+    2022-08-25       requests: 2      bits: 300
+  Top commands:
+    integer          requests: 3      bits: 400
+  Top users:
+    none
+  RequestsLeft:  96
+  BitsLeft:      8600
+  Exhaustion:    12-09-2022`
+
+	rr := &Recorder{}
+
+	outputer := output.NewOutputProcessor(false, false, "", rr)
+
+	err := outputer.GenerateHistoryOutput(history)
+	require.NoError(t, err)
+
+	require.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(rr.String()))
+}
+
+func TestFailedGenerateHistoryOutput(t *testing.T) {
+	rr := &ErrRecorder{err: entities.Error("test error")}
+
+	outputer := output.NewOutputProcessor(true, false, "", rr)
+
+	err := outputer.GenerateHistoryOutput(entities.UsageHistory{})
+	require.EqualError(t, err, "write output: test error")
+}
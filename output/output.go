@@ -6,7 +6,9 @@ import (
 	"log"
 	"strings"
 
+	"github.com/bohdanch-w/rand-api/backend"
 	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/quota"
 	"github.com/bohdanch-w/rand-api/services"
 )
 
@@ -33,6 +35,12 @@ type GeneratorImplementation struct {
 	quiet     bool
 	separator string
 	writer    io.Writer
+	warner    *quota.Warner
+}
+
+// SetWarner enables quota warnings. Without warner no warnings are shown.
+func (svc *GeneratorImplementation) SetWarner(warner *quota.Warner) {
+	svc.warner = warner
 }
 
 func (svc *GeneratorImplementation) GenerateRandOutput(data []interface{}, apiInfo entities.APIInfo) error {
@@ -52,11 +60,18 @@ func (svc *GeneratorImplementation) GenerateRandOutput(data []interface{}, apiIn
 }
 
 func (svc *GeneratorImplementation) generateAPIInfoOutput(apiInfo entities.APIInfo) {
+	// no request was made, e.g. shuffle of a single item
+	if apiInfo == (entities.APIInfo{}) {
+		return
+	}
+
+	warnings := svc.checkQuota(apiInfo)
+
 	if svc.quiet {
 		return
 	}
 
-	svc.showWarnings(apiInfo)
+	svc.showWarnings(warnings)
 
 	if !svc.verbose {
 		return
@@ -66,25 +81,44 @@ func (svc *GeneratorImplementation) generateAPIInfoOutput(apiInfo entities.APIIn
 	log.Printf("requests left: %d\n", apiInfo.RequestsLeft)
 	log.Printf("random bits left: %d\n", apiInfo.BitsLeft)
 	log.Printf("random bits used: %d\n", apiInfo.BitsUsed)
+
+	if apiInfo.Source != "" {
+		log.Printf("values source: %s\n", apiInfo.Source)
+	}
 }
 
-func (svc *GeneratorImplementation) showWarnings(apiInfo entities.APIInfo) {
-	const (
-		maxRequests = 1000
-		maxBits     = 250_000
+// checkQuota evaluates warnings even in quiet mode, so configured hooks still run.
+func (svc *GeneratorImplementation) checkQuota(apiInfo entities.APIInfo) []quota.Warning {
+	if svc.warner == nil || (svc.quiet && !svc.warner.HasHooks()) {
+		return nil
+	}
 
-		percent      = 100
-		warnTreshold = 5.0
-	)
+	// values generated locally don't report key quota
+	if apiInfo.Source == backend.CryptoFallback {
+		return nil
+	}
 
-	requestsLeft := percent * float64(apiInfo.RequestsLeft) / maxRequests
-	bitsLeft := percent * float64(apiInfo.BitsLeft) / maxBits
+	warnings, err := svc.warner.Check(apiInfo)
+	if err != nil && svc.verbose && !svc.quiet {
+		log.Printf("WARN: quota warnings unavailable: %s\n", err)
+	}
 
-	if requestsLeft > warnTreshold && bitsLeft > warnTreshold {
+	return warnings
+}
+
+func (svc *GeneratorImplementation) showWarnings(warnings []quota.Warning) {
+	if len(warnings) == 0 {
 		return
 	}
 
-	log.Printf("WARN: requests left    - %2.2f%% - %d\n", requestsLeft, apiInfo.RequestsLeft)
-	log.Printf("WARN: random bits left - %2.2f%% - %d\n", requestsLeft, apiInfo.BitsLeft)
+	for _, w := range warnings {
+		switch w.Kind {
+		case quota.KindRequests:
+			log.Printf("WARN: requests left    - %2.2f%% - %d\n", w.Percent, w.Left)
+		case quota.KindBits:
+			log.Printf("WARN: random bits left - %2.2f%% - %d\n", w.Percent, w.Left)
+		}
+	}
+
 	log.Println()
 }
@@ -1,12 +1,17 @@
 package output_test
 
 import (
+	"bytes"
+	"context"
+	"log"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/bohdanch-w/rand-api/entities"
 	"github.com/bohdanch-w/rand-api/output"
+	"github.com/bohdanch-w/rand-api/quota"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +77,35 @@ func TestFailedGenerateRandOutput(t *testing.T) {
 	err := outputer.GenerateRandOutput(nil, entities.APIInfo{})
 	require.EqualError(t, err, "write output: test error")
 }
+
+func TestGenerateRandOutput_NoRequest(t *testing.T) {
+	var (
+		logs    bytes.Buffer
+		fetched bool
+	)
+
+	prev := log.Writer()
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(prev)
+
+	limits := quota.NewLimitsCache(filepath.Join(t.TempDir(), "limits.json"), time.Hour,
+		func(context.Context, string) (entities.UsageStatus, error) {
+			fetched = true
+
+			return entities.UsageStatus{TotalRequests: 1000, TotalBits: 250_000}, nil
+		})
+
+	rr := &Recorder{}
+
+	outputer := output.NewOutputProcessor(true, false, " ", rr)
+	outputer.SetWarner(quota.NewWarner(quota.Config{}, "c6418ada-7874-4907-9367-f43c446686d3", limits, time.Second))
+
+	// zero APIInfo means values were produced without any request
+	err := outputer.GenerateRandOutput([]any{"a"}, entities.APIInfo{})
+	require.NoError(t, err)
+
+	require.Equal(t, "a", strings.TrimSpace(rr.String()))
+	require.Empty(t, logs.String())
+	require.False(t, fetched)
+}
@@ -0,0 +1,56 @@
+package pairs
+
+import "fmt"
+
+// Permutations returns batch uniformly random permutations of [0, n).
+type Permutations func(batch int) ([][]int, error)
+
+type Assignment struct {
+	// Receivers[i] is participant Names[i] gives to.
+	Receivers []int
+	// Attempts is number of permutations drawn, the last one is valid.
+	Attempts int
+}
+
+// BatchSize is number of permutations of n requested at once: up to 10,000
+// integers and 10 permutations. Without exclusions 10 permutations contain
+// a derangement with probability about 99%.
+func BatchSize(n int) int {
+	const (
+		integersMax = 10_000
+		batchMax    = 10
+	)
+
+	return max(1, min(batchMax, integersMax/n))
+}
+
+// Assign draws permutations until a valid one is found.
+func (p *Problem) Assign(perms Permutations) (Assignment, error) {
+	if !p.Feasible() {
+		return Assignment{}, ErrImpossible
+	}
+
+	var attempts int
+
+	for attempts < AttemptsMax {
+		batch, err := perms(min(BatchSize(len(p.Names)), AttemptsMax-attempts))
+		if err != nil {
+			return Assignment{}, err
+		}
+
+		if len(batch) == 0 {
+			return Assignment{}, fmt.Errorf("%w: no permutations drawn", ErrTooManyAttempts)
+		}
+
+		for _, receivers := range batch {
+			attempts++
+
+			if p.Valid(receivers) {
+				return Assignment{Receivers: receivers, Attempts: attempts}, nil
+			}
+		}
+	}
+
+	return Assignment{}, fmt.Errorf("%w in %d permutations, exclusions leave too few assignments",
+		ErrTooManyAttempts, attempts)
+}
@@ -0,0 +1,210 @@
+// Package pairs assigns every participant another one to give a gift to,
+// Secret Santa style. Assignment is a permutation without fixed points that
+// avoids excluded pairs. Uniformly random permutations are drawn until one is
+// valid, which makes every valid assignment equally likely.
+package pairs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidParticipant = entities.Error("invalid participant")
+	ErrImpossible         = entities.Error("no assignment satisfies exclusions")
+	ErrTooManyAttempts    = entities.Error("no valid assignment found")
+
+	ParticipantsMin = 2
+	ParticipantsMax = 1_000
+	// AttemptsMax limits permutations drawn before giving up.
+	AttemptsMax = 1_000
+)
+
+// Participant must not draw names in Excludes.
+type Participant struct {
+	Name     string
+	Excludes []string
+}
+
+// ReadCSV reads NAME[,EXCLUDED...] rows: participant and whom they must not draw.
+func ReadCSV(r io.Reader) ([]Participant, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var participants []Participant
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return participants, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		p := Participant{Name: strings.TrimSpace(record[0])}
+
+		for _, name := range record[1:] {
+			if name = strings.TrimSpace(name); name != "" {
+				p.Excludes = append(p.Excludes, name)
+			}
+		}
+
+		participants = append(participants, p)
+	}
+}
+
+// Couple is a pair of participants who don't draw each other.
+type Couple [2]string
+
+// ParseCouple parses NAME:NAME.
+func ParseCouple(s string) (Couple, error) {
+	a, b, ok := strings.Cut(s, ":")
+	if !ok || strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
+		return Couple{}, fmt.Errorf("%w: exclusion %q must be NAME:NAME", ErrInvalidParticipant, s)
+	}
+
+	return Couple{strings.TrimSpace(a), strings.TrimSpace(b)}, nil
+}
+
+// Problem is participants with pairs they can't be assigned to.
+type Problem struct {
+	Names     []string
+	forbidden [][]bool
+}
+
+// NewProblem checks names are unique and exclusions refer to participants.
+// Names are compared case insensitive.
+func NewProblem(participants []Participant, couples []Couple) (*Problem, error) {
+	n := len(participants)
+	if n < ParticipantsMin || n > ParticipantsMax {
+		return nil, fmt.Errorf("%w: %d participants, must be in [%d, %d]",
+			ErrInvalidParticipant, n, ParticipantsMin, ParticipantsMax)
+	}
+
+	p := &Problem{
+		Names:     make([]string, 0, n),
+		forbidden: make([][]bool, n),
+	}
+
+	index := make(map[string]int, n)
+
+	for i, participant := range participants {
+		key := strings.ToLower(participant.Name)
+
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty name", ErrInvalidParticipant)
+		}
+
+		if _, ok := index[key]; ok {
+			return nil, fmt.Errorf("%w: %q is listed twice", ErrInvalidParticipant, participant.Name)
+		}
+
+		index[key] = i
+		p.Names = append(p.Names, participant.Name)
+		p.forbidden[i] = make([]bool, n)
+		p.forbidden[i][i] = true
+	}
+
+	lookup := func(name string) (int, error) {
+		i, ok := index[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown %q in exclusions", ErrInvalidParticipant, name)
+		}
+
+		return i, nil
+	}
+
+	for i, participant := range participants {
+		for _, name := range participant.Excludes {
+			j, err := lookup(name)
+			if err != nil {
+				return nil, err
+			}
+
+			p.forbidden[i][j] = true
+		}
+	}
+
+	for _, c := range couples {
+		a, err := lookup(c[0])
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := lookup(c[1])
+		if err != nil {
+			return nil, err
+		}
+
+		p.forbidden[a][b], p.forbidden[b][a] = true, true
+	}
+
+	return p, nil
+}
+
+// Valid reports whether receivers is a permutation where participant i gives
+// to receivers[i] and no exclusion is broken.
+func (p *Problem) Valid(receivers []int) bool {
+	if len(receivers) != len(p.Names) {
+		return false
+	}
+
+	seen := make([]bool, len(receivers))
+
+	for i, r := range receivers {
+		if r < 0 || r >= len(p.Names) || seen[r] || p.forbidden[i][r] {
+			return false
+		}
+
+		seen[r] = true
+	}
+
+	return true
+}
+
+// Feasible reports whether any valid assignment exists, looking for
+// perfect matching of givers and receivers with augmenting paths.
+func (p *Problem) Feasible() bool {
+	n := len(p.Names)
+	giverOf := make([]int, n)
+
+	for i := range giverOf {
+		giverOf[i] = -1
+	}
+
+	var augment func(giver int, visited []bool) bool
+
+	augment = func(giver int, visited []bool) bool {
+		for r := range n {
+			if p.forbidden[giver][r] || visited[r] {
+				continue
+			}
+
+			visited[r] = true
+
+			if giverOf[r] < 0 || augment(giverOf[r], visited) {
+				giverOf[r] = giver
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for giver := range n {
+		if !augment(giver, make([]bool, n)) {
+			return false
+		}
+	}
+
+	return true
+}
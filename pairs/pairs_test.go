@@ -0,0 +1,171 @@
+package pairs_test
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/pairs"
+)
+
+func TestReadCSV(t *testing.T) {
+	participants, err := pairs.ReadCSV(strings.NewReader("alice, bob\nbob,alice,\ncarol\n"))
+	require.NoError(t, err)
+	require.Equal(t, []pairs.Participant{
+		{Name: "alice", Excludes: []string{"bob"}},
+		{Name: "bob", Excludes: []string{"alice"}},
+		{Name: "carol"},
+	}, participants)
+}
+
+func TestNewProblem_Invalid(t *testing.T) {
+	testCases := []struct {
+		participants  []pairs.Participant
+		couples       []pairs.Couple
+		expectedError string
+	}{
+		{
+			participants:  []pairs.Participant{{Name: "alice"}},
+			expectedError: "invalid participant: 1 participants, must be in [2, 1000]",
+		},
+		{
+			participants:  []pairs.Participant{{Name: "alice"}, {Name: "Alice"}},
+			expectedError: `invalid participant: "Alice" is listed twice`,
+		},
+		{
+			participants:  []pairs.Participant{{Name: "alice", Excludes: []string{"dave"}}, {Name: "bob"}},
+			expectedError: `invalid participant: unknown "dave" in exclusions`,
+		},
+		{
+			participants:  []pairs.Participant{{Name: "alice"}, {Name: "bob"}},
+			couples:       []pairs.Couple{{"alice", "carol"}},
+			expectedError: `invalid participant: unknown "carol" in exclusions`,
+		},
+		{
+			participants:  []pairs.Participant{{Name: "alice"}, {Name: ""}},
+			expectedError: "invalid participant: empty name",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := pairs.NewProblem(tc.participants, tc.couples)
+		require.EqualError(t, err, tc.expectedError)
+	}
+}
+
+func TestParseCouple(t *testing.T) {
+	c, err := pairs.ParseCouple("alice : bob")
+	require.NoError(t, err)
+	require.Equal(t, pairs.Couple{"alice", "bob"}, c)
+
+	_, err = pairs.ParseCouple("alice")
+	require.ErrorIs(t, err, pairs.ErrInvalidParticipant)
+}
+
+func TestProblem_Valid(t *testing.T) {
+	p, err := pairs.NewProblem([]pairs.Participant{
+		{Name: "alice", Excludes: []string{"carol"}}, {Name: "bob"}, {Name: "carol"}, {Name: "dave"},
+	}, []pairs.Couple{{"bob", "DAVE"}})
+	require.NoError(t, err)
+
+	require.True(t, p.Valid([]int{1, 2, 3, 0}))
+	require.False(t, p.Valid([]int{0, 2, 3, 1}), "fixed point")
+	require.False(t, p.Valid([]int{2, 0, 3, 1}), "alice excludes carol")
+	require.False(t, p.Valid([]int{1, 3, 0, 2}), "bob and dave are couple")
+	require.False(t, p.Valid([]int{1, 2, 1, 0}), "not a permutation")
+	require.False(t, p.Valid([]int{1, 2, 0}))
+}
+
+func TestProblem_Feasible(t *testing.T) {
+	p, err := pairs.NewProblem([]pairs.Participant{{Name: "a"}, {Name: "b"}, {Name: "c"}}, nil)
+	require.NoError(t, err)
+	require.True(t, p.Feasible())
+
+	// nobody else can give to c
+	p, err = pairs.NewProblem([]pairs.Participant{
+		{Name: "a", Excludes: []string{"c"}}, {Name: "b", Excludes: []string{"c"}}, {Name: "c"},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, p.Feasible())
+
+	_, err = p.Assign(func(int) ([][]int, error) {
+		require.Fail(t, "permutations are drawn for impossible problem")
+
+		return nil, nil
+	})
+	require.ErrorIs(t, err, pairs.ErrImpossible)
+}
+
+func TestProblem_Assign(t *testing.T) {
+	p, err := pairs.NewProblem([]pairs.Participant{{Name: "a"}, {Name: "b"}, {Name: "c"}}, nil)
+	require.NoError(t, err)
+
+	batches := [][][]int{
+		{{0, 1, 2}, {1, 0, 2}},
+		{{2, 1, 0}, {2, 0, 1}, {1, 2, 0}},
+	}
+
+	assignment, err := p.Assign(func(batch int) ([][]int, error) {
+		require.Equal(t, 10, batch)
+
+		next := batches[0]
+		batches = batches[1:]
+
+		return next, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, pairs.Assignment{Receivers: []int{2, 0, 1}, Attempts: 4}, assignment)
+}
+
+func TestProblem_AssignUniform(t *testing.T) {
+	p, err := pairs.NewProblem([]pairs.Participant{{Name: "a"}, {Name: "b"}, {Name: "c"}}, nil)
+	require.NoError(t, err)
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	perms := func(batch int) ([][]int, error) {
+		res := make([][]int, batch)
+		for i := range res {
+			res[i] = rnd.Perm(3)
+		}
+
+		return res, nil
+	}
+
+	// a->b is in one of two derangements of 3
+	const draws = 2_000
+
+	hits := 0
+
+	for range draws {
+		assignment, err := p.Assign(perms)
+		require.NoError(t, err)
+
+		if assignment.Receivers[0] == 1 {
+			hits++
+		}
+	}
+
+	require.InDelta(t, draws/2, hits, draws/10)
+}
+
+func TestProblem_AssignTooManyAttempts(t *testing.T) {
+	p, err := pairs.NewProblem([]pairs.Participant{{Name: "a"}, {Name: "b"}}, nil)
+	require.NoError(t, err)
+
+	calls := 0
+
+	_, err = p.Assign(func(batch int) ([][]int, error) {
+		calls++
+
+		res := make([][]int, batch)
+		for i := range res {
+			res[i] = []int{0, 1}
+		}
+
+		return res, nil
+	})
+	require.EqualError(t, err, "no valid assignment found in 1000 permutations, exclusions leave too few assignments")
+	require.Equal(t, 100, calls)
+}
@@ -0,0 +1,207 @@
+package passgen
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidWordlist   = entities.Error("invalid wordlist")
+	ErrInvalidPassphrase = entities.Error("invalid passphrase options")
+
+	// DefaultWordlistSize is number of words of EFF large wordlist, one per roll of 5 dice.
+	DefaultWordlistSize = 7776
+
+	WordsMax    = 64
+	WordlistMax = 1_000_000
+	wordlistMin = 2
+)
+
+// effLargeWordlist is EFF large wordlist for dice-generated passphrases.
+//
+//go:embed wordlists/eff_large_wordlist.txt
+var effLargeWordlist string
+
+// Capitalization of passphrase words.
+type Capitalization string
+
+const (
+	CapNone   Capitalization = "none"
+	CapFirst  Capitalization = "first"
+	CapUpper  Capitalization = "upper"
+	CapRandom Capitalization = "random"
+)
+
+func (c Capitalization) Validate() error {
+	switch c {
+	case CapNone, CapFirst, CapUpper, CapRandom:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown capitalization %q", ErrInvalidPassphrase, c)
+	}
+}
+
+// Wordlist is a list of unique words.
+type Wordlist []string
+
+// DefaultWordlist returns embedded EFF large wordlist.
+func DefaultWordlist() (Wordlist, error) {
+	words, err := ParseWordlist(strings.NewReader(effLargeWordlist))
+	if err != nil {
+		return nil, fmt.Errorf("default wordlist: %w", err)
+	}
+
+	if len(words) != DefaultWordlistSize {
+		return nil, fmt.Errorf("default wordlist: %w: %d words, want %d", ErrInvalidWordlist, len(words), DefaultWordlistSize)
+	}
+
+	return words, nil
+}
+
+// ParseWordlist reads one word per line. Lines may be prefixed with dice
+// rolls as in diceware lists, e.g. "11111\tabacus". Empty lines are skipped.
+func ParseWordlist(r io.Reader) (Wordlist, error) {
+	var (
+		words Wordlist
+		seen  = make(map[string]bool)
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) == 2 && isDice(fields[0]):
+			fields = fields[1:]
+		case len(fields) > 1:
+			return nil, fmt.Errorf("%w: line %d: one word per line expected", ErrInvalidWordlist, line)
+		}
+
+		word := fields[0]
+		if seen[word] {
+			return nil, fmt.Errorf("%w: line %d: duplicate word %q", ErrInvalidWordlist, line, word)
+		}
+
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read wordlist: %w", err)
+	}
+
+	if len(words) < wordlistMin || len(words) > WordlistMax {
+		return nil, fmt.Errorf("%w: %d words, must be in [%d, %d]", ErrInvalidWordlist, len(words), wordlistMin, WordlistMax)
+	}
+
+	return words, nil
+}
+
+func isDice(s string) bool {
+	return strings.Trim(s, "123456") == ""
+}
+
+// Passphrase describes passphrases of Words words joined with Delimiter.
+type Passphrase struct {
+	Words          int
+	Delimiter      string
+	Capitalization Capitalization
+	Wordlist       Wordlist
+}
+
+func (p Passphrase) Validate() error {
+	if p.Words < 1 || p.Words > WordsMax {
+		return fmt.Errorf("%w: words must be in [1, %d]", ErrInvalidPassphrase, WordsMax)
+	}
+
+	if len(p.Wordlist) < wordlistMin {
+		return fmt.Errorf("%w: wordlist must have at least %d words", ErrInvalidPassphrase, wordlistMin)
+	}
+
+	return p.Capitalization.Validate()
+}
+
+// Entropy returns bits of entropy of a passphrase. Random capitalization adds
+// a bit per word, lists with words differing only in case are not expected.
+func (p Passphrase) Entropy() float64 {
+	bits := float64(p.Words) * math.Log2(float64(len(p.Wordlist)))
+
+	if p.Capitalization == CapRandom {
+		bits += float64(p.Words)
+	}
+
+	return bits
+}
+
+// Generate returns n passphrases. Words of all of them are drawn in one
+// sequence, coin flips of random capitalization in another.
+func (p Passphrase) Generate(n int, seqs Sequences) ([]Secret, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	total := n * p.Words
+	if n < 1 || total > IntegersMax {
+		return nil, fmt.Errorf("%w: number of words must be in [1, %d]", ErrTooMany, IntegersMax)
+	}
+
+	batch := []Seq{{Length: total, Max: len(p.Wordlist) - 1}}
+	if p.Capitalization == CapRandom {
+		batch = append(batch, Seq{Length: total, Max: 1})
+	}
+
+	values, err := seqs(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res   = make([]Secret, n)
+		words = make([]string, p.Words)
+	)
+
+	for i := range res {
+		for j := range words {
+			k := i*p.Words + j
+			words[j] = p.capitalize(p.Wordlist[values[0][k]], values, k)
+		}
+
+		res[i] = Secret{Value: strings.Join(words, p.Delimiter), Bits: p.Entropy()}
+	}
+
+	return res, nil
+}
+
+func (p Passphrase) capitalize(word string, values [][]int, k int) string {
+	switch p.Capitalization {
+	case CapFirst:
+		return upperFirst(word)
+	case CapUpper:
+		return strings.ToUpper(word)
+	case CapRandom:
+		if values[1][k] == 1 {
+			return upperFirst(word)
+		}
+
+		return word
+	default:
+		return word
+	}
+}
+
+func upperFirst(word string) string {
+	r := []rune(word)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
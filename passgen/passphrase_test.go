@@ -0,0 +1,81 @@
+package passgen_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/passgen"
+)
+
+func TestParseWordlist(t *testing.T) {
+	words, err := passgen.ParseWordlist(strings.NewReader("11111\tabacus\n11112 abdomen\n\nabide\r\n"))
+	require.NoError(t, err)
+	require.Equal(t, passgen.Wordlist{"abacus", "abdomen", "abide"}, words)
+
+	_, err = passgen.ParseWordlist(strings.NewReader("abacus\nabide\nabacus\n"))
+	require.EqualError(t, err, `invalid wordlist: line 3: duplicate word "abacus"`)
+
+	_, err = passgen.ParseWordlist(strings.NewReader("abacus\nice cream\n"))
+	require.ErrorIs(t, err, passgen.ErrInvalidWordlist)
+
+	_, err = passgen.ParseWordlist(strings.NewReader("abacus\n"))
+	require.ErrorIs(t, err, passgen.ErrInvalidWordlist)
+}
+
+func TestDefaultWordlist(t *testing.T) {
+	words, err := passgen.DefaultWordlist()
+	require.NoError(t, err)
+	require.Len(t, words, 7776)
+
+	unique := make(map[string]bool, len(words))
+	for _, w := range words {
+		unique[w] = true
+	}
+
+	require.Len(t, unique, 7776)
+	require.Equal(t, "abacus", words[0])
+	require.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestPassphrase_Generate(t *testing.T) {
+	p := passgen.Passphrase{
+		Words:          3,
+		Delimiter:      ".",
+		Capitalization: passgen.CapRandom,
+		Wordlist:       passgen.Wordlist{"apple", "banana", "cherry", "date"},
+	}
+
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		require.Equal(t, []passgen.Seq{{Length: 6, Max: 3}, {Length: 6, Max: 1}}, batch)
+
+		return [][]int{{0, 1, 2, 3, 3, 0}, {1, 0, 0, 0, 1, 1}}, nil
+	}
+
+	secrets, err := p.Generate(2, seqs)
+	require.NoError(t, err)
+	require.Equal(t, []passgen.Secret{
+		{Value: "Apple.banana.cherry", Bits: 9},
+		{Value: "date.Date.Apple", Bits: 9},
+	}, secrets)
+}
+
+func TestPassphrase_Entropy(t *testing.T) {
+	p := passgen.Passphrase{Words: 6, Capitalization: passgen.CapFirst, Wordlist: make(passgen.Wordlist, 7776)}
+	require.InDelta(t, 6*math.Log2(7776), p.Entropy(), 1e-9)
+}
+
+func TestPassphrase_Validate(t *testing.T) {
+	words := passgen.Wordlist{"apple", "banana"}
+
+	for _, p := range []passgen.Passphrase{
+		{Words: 0, Capitalization: passgen.CapNone, Wordlist: words},
+		{Words: 65, Capitalization: passgen.CapNone, Wordlist: words},
+		{Words: 6, Capitalization: "title", Wordlist: words},
+		{Words: 6, Capitalization: passgen.CapNone},
+	} {
+		require.ErrorIs(t, p.Validate(), passgen.ErrInvalidPassphrase, "%+v", p)
+	}
+}
@@ -0,0 +1,399 @@
+// Package passgen builds passwords and passphrases from random integers
+// and reports entropy of every result: -log2 of its probability under the
+// policy, which is exact because every choice is uniform.
+package passgen
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidPolicy   = entities.Error("invalid password policy")
+	ErrPolicyTooStrict = entities.Error("password policy is too strict")
+	ErrTooManyAttempts = entities.Error("too many rejected passwords")
+	ErrTooMany         = entities.Error("too many values requested")
+
+	Lower = "abcdefghijklmnopqrstuvwxyz"
+	Upper = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digit = "0123456789"
+	// Symbols are default symbols, characters that need quoting in shells are left out.
+	Symbols = "!#%+-.=?@^_~"
+	// Ambiguous characters are easy to confuse when read.
+	Ambiguous = "0Oo1Il|"
+
+	LengthMax = 128
+	// AcceptanceMin is the lowest share of random strings satisfying policy.
+	AcceptanceMin = 0.001
+	// RoundMax limits requests for candidates replacing rejected ones.
+	RoundMax = 100
+	// SequencesMax and IntegersMax limit a single sequences request.
+	SequencesMax = 1000
+	IntegersMax  = 10_000
+)
+
+// Policy of passwords. Characters of all classes form alphabet, min counts
+// must be met by every password.
+type Policy struct {
+	MinLength int
+	MaxLength int
+	MinLower  int
+	MinUpper  int
+	MinDigits int
+	// Symbols class, no symbols if empty.
+	Symbols    string
+	MinSymbols int
+	// ExcludeAmbiguous removes Ambiguous characters from all classes.
+	ExcludeAmbiguous bool
+}
+
+type class struct {
+	chars string
+	min   int
+}
+
+func (p Policy) classes() []class {
+	classes := []class{
+		{chars: Lower, min: p.MinLower},
+		{chars: Upper, min: p.MinUpper},
+		{chars: Digit, min: p.MinDigits},
+		{chars: dedup(p.Symbols), min: p.MinSymbols},
+	}
+
+	for i := range classes {
+		if p.ExcludeAmbiguous {
+			classes[i].chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(Ambiguous, r) {
+					return -1
+				}
+
+				return r
+			}, classes[i].chars)
+		}
+	}
+
+	return classes
+}
+
+func dedup(s string) string {
+	var sb strings.Builder
+
+	for _, r := range s {
+		if !strings.ContainsRune(sb.String(), r) {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// Alphabet returns characters passwords consist of.
+func (p Policy) Alphabet() string {
+	var sb strings.Builder
+
+	for _, c := range p.classes() {
+		sb.WriteString(c.chars)
+	}
+
+	return sb.String()
+}
+
+func (p Policy) Validate() error {
+	if p.MinLength < 1 || p.MaxLength < p.MinLength || p.MaxLength > LengthMax {
+		return fmt.Errorf("%w: length must be in [1, %d], min no greater than max", ErrInvalidPolicy, LengthMax)
+	}
+
+	if utf8.RuneCountInString(p.Alphabet()) < 2 { //nolint: gomnd
+		return fmt.Errorf("%w: alphabet must have at least 2 characters", ErrInvalidPolicy)
+	}
+
+	if strings.ContainsAny(p.Symbols, Lower+Upper+Digit) {
+		return fmt.Errorf("%w: symbols must not contain letters or digits", ErrInvalidPolicy)
+	}
+
+	required := 0
+
+	for _, c := range p.classes() {
+		if c.min < 0 {
+			return fmt.Errorf("%w: min counts must not be negative", ErrInvalidPolicy)
+		}
+
+		if c.min > 0 && c.chars == "" {
+			return fmt.Errorf("%w: min count of class without characters", ErrInvalidPolicy)
+		}
+
+		required += c.min
+	}
+
+	if required > p.MinLength {
+		return fmt.Errorf("%w: min counts sum to %d, more than min length %d", ErrInvalidPolicy, required, p.MinLength)
+	}
+
+	for l := p.MinLength; l <= p.MaxLength; l++ {
+		if rate := p.acceptance(l); rate < AcceptanceMin {
+			return fmt.Errorf("%w: %.4f%% of random %d character passwords satisfy it", ErrPolicyTooStrict, rate*100, l)
+		}
+	}
+
+	return nil
+}
+
+// Count returns number of passwords of length satisfying policy.
+func (p Policy) Count(length int) *big.Int {
+	// ways[j] counts strings of j characters of processed classes meeting their mins
+	ways := []*big.Int{big.NewInt(1)}
+
+	for _, c := range p.classes() {
+		var (
+			next = make([]*big.Int, length+1)
+			size = big.NewInt(int64(utf8.RuneCountInString(c.chars)))
+		)
+
+		for j := range next {
+			next[j] = new(big.Int)
+		}
+
+		for j, w := range ways {
+			if w.Sign() == 0 {
+				continue
+			}
+
+			for k := c.min; j+k <= length; k++ {
+				// choose positions of k characters among j+k and fill them
+				term := new(big.Int).Binomial(int64(j+k), int64(k))
+				term.Mul(term, new(big.Int).Exp(size, big.NewInt(int64(k)), nil))
+				term.Mul(term, w)
+				next[j+k].Add(next[j+k], term)
+
+				if c.chars == "" {
+					break
+				}
+			}
+		}
+
+		ways = next
+	}
+
+	return ways[length]
+}
+
+// Entropy returns bits of entropy of a password of length: length is uniform in
+// [MinLength, MaxLength] and the password is uniform among ones of that length.
+func (p Policy) Entropy(length int) float64 {
+	return log2(p.Count(length)) + math.Log2(float64(p.MaxLength-p.MinLength+1))
+}
+
+func (p Policy) acceptance(length int) float64 {
+	all := new(big.Int).Exp(big.NewInt(int64(utf8.RuneCountInString(p.Alphabet()))), big.NewInt(int64(length)), nil)
+
+	return math.Exp2(log2(p.Count(length)) - log2(all))
+}
+
+// Accept reports whether password meets min counts.
+func (p Policy) Accept(password string) bool {
+	for _, c := range p.classes() {
+		n := 0
+
+		for _, r := range password {
+			if strings.ContainsRune(c.chars, r) {
+				n++
+			}
+		}
+
+		if n < c.min {
+			return false
+		}
+	}
+
+	return true
+}
+
+func log2(n *big.Int) float64 {
+	if n.Sign() <= 0 {
+		return math.Inf(-1)
+	}
+
+	f, _ := new(big.Float).SetInt(n).Float64()
+	if !math.IsInf(f, 0) {
+		return math.Log2(f)
+	}
+
+	// keep 64 leading bits
+	shift := n.BitLen() - 64
+	f, _ = new(big.Float).SetInt(new(big.Int).Rsh(n, uint(shift))).Float64()
+
+	return math.Log2(f) + float64(shift)
+}
+
+// Seq is a sequence of Length integers in [0, Max], drawn with replacement.
+type Seq struct {
+	Length int
+	Max    int
+}
+
+// Sequences returns random integer sequences, one per seq.
+type Sequences func(seqs []Seq) ([][]int, error)
+
+// Secret is a generated password or passphrase with its entropy in bits.
+type Secret struct {
+	Value string
+	Bits  float64
+}
+
+// Generate returns n passwords. Length of each is drawn first, then random
+// strings over alphabet of that length are drawn until enough meet min counts.
+// Accepted candidates are uniform among passwords meeting policy, so no class
+// is favoured.
+func (p Policy) Generate(n int, seqs Sequences) ([]Secret, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if n < 1 || n > SequencesMax {
+		return nil, fmt.Errorf("%w: number must be in [1, %d]", ErrTooMany, SequencesMax)
+	}
+
+	lengths, err := p.lengths(n, seqs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		alphabet   = []rune(p.Alphabet())
+		res        = make([]Secret, n)
+		pending    = make([]int, n)
+		acceptance = make(map[int]float64)
+	)
+
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for l := p.MinLength; l <= p.MaxLength; l++ {
+		acceptance[l] = p.acceptance(l)
+	}
+
+	for round := 0; len(pending) > 0; round++ {
+		if round == RoundMax {
+			return nil, fmt.Errorf("%w: %d rounds", ErrTooManyAttempts, RoundMax)
+		}
+
+		batch := candidates(pending, lengths, acceptance, len(alphabet)-1)
+
+		values, err := seqs(batch)
+		if err != nil {
+			return nil, err
+		}
+
+		// accepted candidates of a length go to pending passwords of that length in order
+		waiting := make(map[int][]int)
+
+		for _, i := range pending {
+			waiting[lengths[i]] = append(waiting[lengths[i]], i)
+		}
+
+		for k, v := range values {
+			l := batch[k].Length
+
+			queue := waiting[l]
+			if len(queue) == 0 {
+				continue
+			}
+
+			password := make([]rune, len(v))
+			for j, idx := range v {
+				password[j] = alphabet[idx]
+			}
+
+			if p.Accept(string(password)) {
+				res[queue[0]] = Secret{Value: string(password), Bits: p.Entropy(l)}
+				waiting[l] = queue[1:]
+			}
+		}
+
+		next := pending[:0]
+
+		for _, i := range pending {
+			if res[i].Value == "" {
+				next = append(next, i)
+			}
+		}
+
+		pending = next
+	}
+
+	return res, nil
+}
+
+func (p Policy) lengths(n int, seqs Sequences) ([]int, error) {
+	lengths := make([]int, n)
+
+	if p.MinLength == p.MaxLength {
+		for i := range lengths {
+			lengths[i] = p.MinLength
+		}
+
+		return lengths, nil
+	}
+
+	values, err := seqs([]Seq{{Length: n, Max: p.MaxLength - p.MinLength}})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range values[0] {
+		lengths[i] = p.MinLength + v
+	}
+
+	return lengths, nil
+}
+
+// candidates returns a request with as many candidates of every pending length
+// as are expected to be accepted for its pending passwords, within limits.
+// Shortfall is drawn in next rounds.
+func candidates(pending, lengths []int, acceptance map[int]float64, maxIndex int) []Seq {
+	var (
+		order []int
+		want  = make(map[int]int)
+	)
+
+	for _, i := range pending {
+		l := lengths[i]
+		if _, ok := want[l]; !ok {
+			order = append(order, l)
+		}
+
+		want[l]++
+	}
+
+	for l, m := range want {
+		want[l] = int(math.Ceil(float64(m) / acceptance[l]))
+	}
+
+	var (
+		batch []Seq
+		total int
+	)
+
+	for added := true; added; {
+		added = false
+
+		for _, l := range order {
+			if len(batch) == SequencesMax || total+l > IntegersMax || want[l] == 0 {
+				continue
+			}
+
+			batch = append(batch, Seq{Length: l, Max: maxIndex})
+			total += l
+			want[l]--
+			added = true
+		}
+	}
+
+	return batch
+}
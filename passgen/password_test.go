@@ -0,0 +1,187 @@
+package passgen_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/passgen"
+)
+
+func TestPolicy_Alphabet(t *testing.T) {
+	p := passgen.Policy{Symbols: "!!@", ExcludeAmbiguous: true}
+	require.Equal(t, "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789!@", p.Alphabet())
+}
+
+func TestPolicy_Count(t *testing.T) {
+	p := passgen.Policy{MinLength: 3, MaxLength: 3, MinUpper: 1, MinDigits: 1, Symbols: "!"}
+
+	var (
+		alphabet = []rune(p.Alphabet())
+		count    int64
+	)
+
+	for _, a := range alphabet {
+		for _, b := range alphabet {
+			for _, c := range alphabet {
+				if p.Accept(string([]rune{a, b, c})) {
+					count++
+				}
+			}
+		}
+	}
+
+	require.Equal(t, big.NewInt(count), p.Count(3))
+	require.InDelta(t, math.Log2(float64(count)), p.Entropy(3), 1e-9)
+}
+
+func TestPolicy_Entropy(t *testing.T) {
+	p := passgen.Policy{MinLength: 100, MaxLength: 103, Symbols: passgen.Symbols}
+
+	// 74^100 overflows float64 mantissa but not log
+	require.InDelta(t, 100*math.Log2(74)+2, p.Entropy(100), 1e-9)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	testCases := []struct {
+		policy   passgen.Policy
+		expected error
+	}{
+		{policy: passgen.Policy{MinLength: 0, MaxLength: 8}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 9, MaxLength: 8}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 129}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 8, Symbols: "a!"}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 8, MinSymbols: 1}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 8, MinUpper: -1}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 10, MinUpper: 5, MinDigits: 4}, expected: passgen.ErrInvalidPolicy},
+		{policy: passgen.Policy{MinLength: 8, MaxLength: 8, MinDigits: 8}, expected: passgen.ErrPolicyTooStrict},
+	}
+
+	for _, tc := range testCases {
+		require.ErrorIs(t, tc.policy.Validate(), tc.expected, "%+v", tc.policy)
+	}
+
+	require.NoError(t, passgen.Policy{MinLength: 8, MaxLength: 8, MinUpper: 2, MinDigits: 2}.Validate())
+}
+
+func TestPolicy_Generate(t *testing.T) {
+	p := passgen.Policy{MinLength: 4, MaxLength: 4, MinDigits: 1}
+
+	calls := 0
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		calls++
+
+		// 1 / (1 - (52/62)^4) candidates per password, rounded up
+		require.Len(t, batch, 2)
+
+		values := make([][]int, len(batch))
+		for i, s := range batch {
+			require.Equal(t, passgen.Seq{Length: 4, Max: 61}, s)
+
+			values[i] = []int{52, 52, 52, 52}
+		}
+
+		values[0] = []int{0, 1, 2, 3}    // abcd, no digits
+		values[1] = []int{26, 27, 55, 0} // AB3a
+
+		return values, nil
+	}
+
+	secrets, err := p.Generate(1, seqs)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "AB3a", secrets[0].Value)
+	require.InDelta(t, math.Log2(62*62*62*62-52*52*52*52), secrets[0].Bits, 1e-9)
+}
+
+func TestPolicy_Generate_TopUp(t *testing.T) {
+	p := passgen.Policy{MinLength: 4, MaxLength: 4, MinDigits: 1}
+
+	var sizes []int
+
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		sizes = append(sizes, len(batch))
+
+		values := make([][]int, len(batch))
+		for i := range values {
+			values[i] = []int{0, 0, 0, 0}
+		}
+
+		// a single candidate of every round is accepted
+		values[0] = []int{52, 0, 0, 0}
+
+		return values, nil
+	}
+
+	secrets, err := p.Generate(3, seqs)
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+	// only passwords still pending are drawn for
+	require.Equal(t, []int{6, 4, 2}, sizes)
+}
+
+func TestPolicy_Generate_NonASCIISymbols(t *testing.T) {
+	p := passgen.Policy{MinLength: 1, MaxLength: 1, Symbols: "€£", MinSymbols: 1}
+
+	require.Equal(t, big.NewInt(2), p.Count(1))
+
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		values := make([][]int, len(batch))
+		for i, s := range batch {
+			require.Equal(t, 63, s.Max)
+
+			values[i] = []int{63}
+		}
+
+		return values, nil
+	}
+
+	secrets, err := p.Generate(1, seqs)
+	require.NoError(t, err)
+	require.Equal(t, "£", secrets[0].Value)
+	require.InDelta(t, 1, secrets[0].Bits, 1e-9)
+}
+
+func TestPolicy_Generate_LengthRange(t *testing.T) {
+	p := passgen.Policy{MinLength: 2, MaxLength: 3}
+
+	var batches [][]passgen.Seq
+
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		batches = append(batches, batch)
+
+		if len(batches) == 1 {
+			return [][]int{{1, 0}}, nil
+		}
+
+		values := make([][]int, len(batch))
+		for i, s := range batch {
+			values[i] = make([]int, s.Length)
+		}
+
+		return values, nil
+	}
+
+	secrets, err := p.Generate(2, seqs)
+	require.NoError(t, err)
+	require.Equal(t, []passgen.Seq{{Length: 2, Max: 1}}, batches[0])
+	require.Equal(t, []passgen.Seq{{Length: 3, Max: 61}, {Length: 2, Max: 61}}, batches[1][:2])
+	require.Equal(t, "aaa", secrets[0].Value)
+	require.Equal(t, "aa", secrets[1].Value)
+	require.InDelta(t, 3*math.Log2(62)+1, secrets[0].Bits, 1e-9)
+	require.InDelta(t, 2*math.Log2(62)+1, secrets[1].Bits, 1e-9)
+}
+
+func TestPolicy_Generate_TooManyAttempts(t *testing.T) {
+	p := passgen.Policy{MinLength: 4, MaxLength: 4, MinDigits: 1}
+
+	seqs := func(batch []passgen.Seq) ([][]int, error) {
+		return make([][]int, len(batch)), nil
+	}
+
+	_, err := p.Generate(1, seqs)
+	require.ErrorIs(t, err, passgen.ErrTooManyAttempts)
+}
@@ -0,0 +1,270 @@
+// Package derive turns a stream of random bytes into typed values with the
+// semantics of the corresponding random.org methods. Any byte source can be
+// used: decoded blobs, pooled data or crypto/rand.
+package derive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrNotEnoughValues = entities.Error("range has fewer distinct values than requested")
+	ErrEmptyCharset    = entities.Error("characters must not be empty")
+)
+
+// Uniform returns value in [0, bound) using rejection sampling, so every
+// value is equally likely. It reads the fewest big-endian bytes that can hold
+// bound-1. Zero bound means the whole uint64 range.
+func Uniform(r io.Reader, bound uint64) (uint64, error) {
+	if bound == 1 {
+		return 0, nil
+	}
+
+	size := 8
+
+	if bound != 0 {
+		size = (bits.Len64(bound-1) + 7) / 8 // nolint: gomnd
+	}
+
+	// largest multiple of bound that fits into size bytes
+	var limit uint64
+
+	switch {
+	case bound == 0:
+		limit = 0
+	case size == 8: // nolint: gomnd
+		limit = math.MaxUint64 - (math.MaxUint64%bound+1)%bound
+	default:
+		space := uint64(1) << (8 * size) // nolint: gomnd
+		limit = space - space%bound - 1
+	}
+
+	buf := make([]byte, 8) // nolint: gomnd
+
+	for {
+		if _, err := io.ReadFull(r, buf[8-size:]); err != nil {
+			return 0, err // nolint: wrapcheck
+		}
+
+		v := binary.BigEndian.Uint64(buf)
+
+		if bound == 0 {
+			return v, nil
+		}
+
+		if v <= limit {
+			return v % bound, nil
+		}
+	}
+}
+
+// Float returns uniform value in (0, 1] with 53 bits of precision.
+func Float(r io.Reader) (float64, error) {
+	v, err := Uniform(r, 1<<53) // nolint: gomnd
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(v+1) / (1 << 53), nil // nolint: gomnd
+}
+
+// Integers mirrors random.org generateIntegers: n values in [min, max],
+// distinct unless replacement is set.
+func Integers(r io.Reader, n int, min, max int64, replacement bool) ([]int64, error) {
+	bound := uint64(max-min) + 1
+
+	if !replacement && bound != 0 && uint64(n) > bound {
+		return nil, fmt.Errorf("%w: [%d, %d]", ErrNotEnoughValues, min, max)
+	}
+
+	var (
+		res  = make([]int64, 0, n)
+		seen = make(map[int64]struct{}, n)
+	)
+
+	for len(res) < n {
+		v, err := Uniform(r, bound)
+		if err != nil {
+			return nil, err
+		}
+
+		value := min + int64(v)
+
+		if !replacement {
+			if _, ok := seen[value]; ok {
+				continue
+			}
+
+			seen[value] = struct{}{}
+		}
+
+		res = append(res, value)
+	}
+
+	return res, nil
+}
+
+// Decimals mirrors random.org generateDecimalFractions: values in [0, 1)
+// with given number of decimal places.
+func Decimals(r io.Reader, n, places int, replacement bool) ([]float64, error) {
+	scale := math.Pow10(places)
+
+	ints, err := Integers(r, n, 0, int64(scale)-1, replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]float64, 0, n)
+
+	for _, v := range ints {
+		res = append(res, float64(v)/scale)
+	}
+
+	return res, nil
+}
+
+// Gaussians mirrors random.org generateGaussians using Box-Muller transform.
+// Values are rounded to significantDigits.
+func Gaussians(r io.Reader, n int, mean, deviation float64, significantDigits int) ([]float64, error) {
+	res := make([]float64, 0, n)
+
+	for len(res) < n {
+		u1, err := Float(r)
+		if err != nil {
+			return nil, err
+		}
+
+		u2, err := Float(r)
+		if err != nil {
+			return nil, err
+		}
+
+		z := math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2) // nolint: gomnd
+
+		// explicit conversion forbids fused multiply-add, which differs between platforms
+		res = append(res, roundSignificant(mean+float64(z*deviation), significantDigits))
+	}
+
+	return res, nil
+}
+
+func roundSignificant(v float64, digits int) float64 {
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return v
+	}
+
+	scale := math.Pow10(digits - int(math.Ceil(math.Log10(math.Abs(v)))))
+
+	return math.Round(float64(v*scale)) / scale
+}
+
+// Strings mirrors random.org generateStrings: n strings of length runes
+// from characters, distinct unless replacement is set.
+func Strings(r io.Reader, n, length int, characters string, replacement bool) ([]string, error) {
+	var (
+		charset = []rune(characters)
+		res     = make([]string, 0, n)
+		seen    = make(map[string]struct{}, n)
+	)
+
+	if len(charset) == 0 {
+		return nil, ErrEmptyCharset
+	}
+
+	if !replacement && math.Pow(float64(len(charset)), float64(length)) < float64(n) {
+		return nil, fmt.Errorf("%w: %d strings of length %d", ErrNotEnoughValues, n, length)
+	}
+
+	for len(res) < n {
+		s := make([]rune, 0, length)
+
+		for len(s) < length {
+			i, err := Uniform(r, uint64(len(charset)))
+			if err != nil {
+				return nil, err
+			}
+
+			s = append(s, charset[i])
+		}
+
+		if !replacement {
+			if _, ok := seen[string(s)]; ok {
+				continue
+			}
+
+			seen[string(s)] = struct{}{}
+		}
+
+		res = append(res, string(s))
+	}
+
+	return res, nil
+}
+
+// UUIDs mirrors random.org generateUUIDs returning RFC 4122 version 4 UUIDs.
+func UUIDs(r io.Reader, n int) ([]uuid.UUID, error) {
+	res := make([]uuid.UUID, 0, n)
+
+	for len(res) < n {
+		var u uuid.UUID
+
+		if _, err := io.ReadFull(r, u[:]); err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		u[6] = u[6]&0x0f | 0x40 // version 4
+		u[8] = u[8]&0x3f | 0x80 // variant 10
+
+		res = append(res, u)
+	}
+
+	return res, nil
+}
+
+// Blobs mirrors random.org generateBlobs: n blobs of size bytes each.
+func Blobs(r io.Reader, n, size int) ([][]byte, error) {
+	res := make([][]byte, 0, n)
+
+	for len(res) < n {
+		b := make([]byte, size)
+
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		res = append(res, b)
+	}
+
+	return res, nil
+}
+
+// CountingReader counts bytes read through it, so a source knows how many
+// bytes values were derived from.
+type CountingReader struct {
+	r io.Reader
+	n int
+}
+
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += n
+
+	return n, err // nolint: wrapcheck
+}
+
+// Count returns number of bytes read so far.
+func (r *CountingReader) Count() int {
+	return r.n
+}
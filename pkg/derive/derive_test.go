@@ -0,0 +1,138 @@
+package derive_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+)
+
+func TestUniform(t *testing.T) {
+	testcases := []struct {
+		name     string
+		in       []byte
+		bound    uint64
+		expected uint64
+		read     int
+	}{
+		{
+			name:     "single byte",
+			in:       []byte{7},
+			bound:    10,
+			expected: 7,
+			read:     1,
+		},
+		{
+			name:     "rejects biased tail",
+			in:       []byte{250, 251, 13}, // 250..255 would favour 0..5
+			bound:    10,
+			expected: 3,
+			read:     3,
+		},
+		{
+			name:     "two bytes big-endian",
+			in:       []byte{0x01, 0x02},
+			bound:    1000,
+			expected: 258,
+			read:     2,
+		},
+		{
+			name:     "single value",
+			bound:    1,
+			expected: 0,
+		},
+		{
+			name:     "full range",
+			in:       []byte{0, 0, 0, 0, 0, 0, 1, 0},
+			bound:    0,
+			expected: 256,
+			read:     8,
+		},
+	}
+
+	for _, tc := range testcases {
+		r := bytes.NewReader(tc.in)
+
+		v, err := derive.Uniform(r, tc.bound)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, v, tc.name)
+		require.Equal(t, tc.read, len(tc.in)-r.Len(), tc.name)
+	}
+
+	_, err := derive.Uniform(bytes.NewReader(nil), 10)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestCountingReader(t *testing.T) {
+	r := derive.NewCountingReader(bytes.NewReader([]byte{1, 2, 3}))
+
+	_, err := derive.Uniform(r, 1<<16)
+	require.NoError(t, err)
+	require.Equal(t, 2, r.Count())
+
+	_, err = derive.Uniform(r, 1<<16)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, 3, r.Count())
+}
+
+func TestIntegers(t *testing.T) {
+	values, err := derive.Integers(rand.Reader, 21, -10, 10, false)
+	require.NoError(t, err)
+
+	expected := make([]int64, 0, 21)
+	for i := int64(-10); i <= 10; i++ {
+		expected = append(expected, i)
+	}
+
+	require.ElementsMatch(t, expected, values)
+
+	_, err = derive.Integers(rand.Reader, 22, -10, 10, false)
+	require.ErrorIs(t, err, derive.ErrNotEnoughValues)
+}
+
+func TestDecimals(t *testing.T) {
+	values, err := derive.Decimals(bytes.NewReader([]byte{0, 99, 42}), 3, 2, true)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 0.99, 0.42}, values)
+}
+
+func TestGaussians(t *testing.T) {
+	values, err := derive.Gaussians(rand.Reader, 1000, 500, 1, 4)
+	require.NoError(t, err)
+
+	var sum float64
+
+	for _, v := range values {
+		sum += v
+		// four significant digits around 500 leave one decimal place
+		require.InDelta(t, math.Round(v*10), v*10, 1e-6)
+	}
+
+	require.InDelta(t, 500, sum/float64(len(values)), 0.5)
+}
+
+func TestStrings(t *testing.T) {
+	values, err := derive.Strings(rand.Reader, 4, 1, "абвг", false)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"а", "б", "в", "г"}, values)
+
+	_, err = derive.Strings(rand.Reader, 5, 1, "абвг", false)
+	require.ErrorIs(t, err, derive.ErrNotEnoughValues)
+
+	_, err = derive.Strings(rand.Reader, 1, 1, "", true)
+	require.ErrorIs(t, err, derive.ErrEmptyCharset)
+}
+
+func TestUUIDs(t *testing.T) {
+	values, err := derive.UUIDs(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)), 1)
+	require.NoError(t, err)
+	require.Equal(t, uuid.MustParse("ffffffff-ffff-4fff-bfff-ffffffffffff"), values[0])
+	require.Equal(t, uuid.Version(4), values[0].Version())
+	require.Equal(t, uuid.RFC4122, values[0].Variant())
+}
@@ -0,0 +1,252 @@
+package pool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+)
+
+const (
+	ErrPoolExhausted = entities.Error("entropy pool exhausted")
+	ErrPoolLocked    = entities.Error("entropy pool is locked by another process")
+
+	// SourceRandomOrg marks chunks fetched with random.org generateBlobs.
+	SourceRandomOrg = "random.org"
+
+	appDir   = "randapi"
+	poolFile = "pool.json"
+	filePerm = 0o600
+	dirPerm  = 0o700
+
+	lockRetry   = 50 * time.Millisecond
+	lockTimeout = 5 * time.Second
+)
+
+// Chunk is a piece of pooled randomness. Data holds only bytes not consumed yet.
+type Chunk struct {
+	Source    string    `json:"source"`
+	RequestID uuid.UUID `json:"requestId"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Size      int       `json:"size"`
+	Data      []byte    `json:"data"`
+	// SerialNumber and Signature of signed chunks, random.org getResult returns
+	// the signed object by serial number for verifySignature.
+	SerialNumber int64  `json:"serialNumber,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+}
+
+type file struct {
+	Chunks []Chunk `json:"chunks"`
+}
+
+type Status struct {
+	Bytes  int
+	Chunks int
+	Oldest time.Time
+	Newest time.Time
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, appDir, poolFile), nil
+}
+
+func New(path string) *Pool {
+	return &Pool{path: path}
+}
+
+// Pool is a file of prefetched random bytes. Every byte is handed out once:
+// consumed bytes are removed from the file before values derived from them are returned.
+type Pool struct {
+	path string
+}
+
+func (p *Pool) Path() string {
+	return p.path
+}
+
+func (p *Pool) Add(chunk Chunk) error {
+	return p.update(func(f *file) error {
+		f.Chunks = append(f.Chunks, chunk)
+
+		return nil
+	})
+}
+
+// Consume passes pooled bytes to fn and removes those fn has read.
+// Nothing is removed when fn fails. It returns number of bytes consumed and left.
+func (p *Pool) Consume(fn func(r io.Reader) error) (int, int, error) {
+	var used, left int
+
+	err := p.update(func(f *file) error {
+		var (
+			all = bytes.Join(chunkData(f.Chunks), nil)
+			r   = derive.NewCountingReader(bytes.NewReader(all))
+		)
+
+		if err := fn(r); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("%w: %d bytes left", ErrPoolExhausted, len(all))
+			}
+
+			return err
+		}
+
+		used, left = r.Count(), len(all)-r.Count()
+		f.Chunks = drop(f.Chunks, used)
+
+		return nil
+	})
+
+	return used, left, err
+}
+
+func (p *Pool) Status() (Status, error) {
+	var status Status
+
+	f, err := p.read()
+	if err != nil {
+		return status, err
+	}
+
+	for _, c := range f.Chunks {
+		status.Bytes += len(c.Data)
+		status.Chunks++
+
+		if status.Oldest.IsZero() || c.FetchedAt.Before(status.Oldest) {
+			status.Oldest = c.FetchedAt
+		}
+
+		if c.FetchedAt.After(status.Newest) {
+			status.Newest = c.FetchedAt
+		}
+	}
+
+	return status, nil
+}
+
+func (p *Pool) read() (file, error) {
+	var f file
+
+	data, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+
+	if err != nil {
+		return f, fmt.Errorf("read pool: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("decode pool: %w", err)
+	}
+
+	return f, nil
+}
+
+// update applies fn to pool content under lock and atomically replaces the file.
+func (p *Pool) update(fn func(f *file) error) error {
+	if err := os.MkdirAll(filepath.Dir(p.path), dirPerm); err != nil {
+		return fmt.Errorf("create pool dir: %w", err)
+	}
+
+	unlock, err := p.lock()
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	f, err := p.read()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&f); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("encode pool: %w", err)
+	}
+
+	tmp := p.path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, filePerm); err != nil {
+		return fmt.Errorf("write pool: %w", err)
+	}
+
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("replace pool: %w", err)
+	}
+
+	return nil
+}
+
+// lock guards pool between processes with exclusively created lock file.
+func (p *Pool) lock() (func(), error) {
+	var (
+		path     = p.path + ".lock"
+		deadline = time.Now().Add(lockTimeout)
+	)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePerm)
+		if err == nil {
+			f.Close()
+
+			return func() { os.Remove(path) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock pool: %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: remove %s if no randapi is running", ErrPoolLocked, path)
+		}
+
+		time.Sleep(lockRetry)
+	}
+}
+
+func chunkData(chunks []Chunk) [][]byte {
+	data := make([][]byte, 0, len(chunks))
+
+	for _, c := range chunks {
+		data = append(data, c.Data)
+	}
+
+	return data
+}
+
+// drop removes first n bytes from chunks, deleting chunks left empty.
+func drop(chunks []Chunk, n int) []Chunk {
+	for n > 0 && len(chunks) > 0 {
+		if n < len(chunks[0].Data) {
+			chunks[0].Data = chunks[0].Data[n:]
+
+			return chunks
+		}
+
+		n -= len(chunks[0].Data)
+		chunks = chunks[1:]
+	}
+
+	return chunks
+}
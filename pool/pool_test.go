@@ -0,0 +1,81 @@
+package pool_test
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/pool"
+)
+
+func newPool(t *testing.T, data ...[]byte) *pool.Pool {
+	t.Helper()
+
+	p := pool.New(filepath.Join(t.TempDir(), "pool.json"))
+
+	for i, d := range data {
+		require.NoError(t, p.Add(pool.Chunk{
+			Source:    pool.SourceRandomOrg,
+			RequestID: uuid.New(),
+			FetchedAt: time.Date(2022, 8, 25, 12, i, 0, 0, time.UTC),
+			Size:      len(d),
+			Data:      d,
+		}))
+	}
+
+	return p
+}
+
+func TestPoolConsume(t *testing.T) {
+	p := newPool(t, []byte{1, 2, 3}, []byte{4, 5})
+
+	var first []byte
+
+	used, left, err := p.Consume(func(r io.Reader) error {
+		first = make([]byte, 4)
+		_, err := io.ReadFull(r, first)
+
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4}, first)
+	require.Equal(t, 4, used)
+	require.Equal(t, 1, left)
+
+	// consumed bytes are never handed out again
+	var rest bytes.Buffer
+
+	_, _, err = p.Consume(func(r io.Reader) error {
+		_, err := io.Copy(&rest, r)
+
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{5}, rest.Bytes())
+
+	st, err := p.Status()
+	require.NoError(t, err)
+	require.Equal(t, pool.Status{}, st)
+}
+
+func TestPoolConsume_Exhausted(t *testing.T) {
+	p := newPool(t, []byte{1, 2, 3})
+
+	_, _, err := p.Consume(func(r io.Reader) error {
+		_, err := io.ReadFull(r, make([]byte, 4))
+
+		return err
+	})
+	require.ErrorIs(t, err, pool.ErrPoolExhausted)
+
+	st, err := p.Status()
+	require.NoError(t, err)
+	require.Equal(t, 3, st.Bytes)
+	require.Equal(t, 1, st.Chunks)
+	require.Equal(t, time.Date(2022, 8, 25, 12, 0, 0, 0, time.UTC), st.Oldest)
+}
@@ -0,0 +1,112 @@
+package quota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidHook     = entities.Error("invalid hook")
+	ErrUnexpectedReply = entities.Error("unexpected webhook status code")
+)
+
+// Hook is executed when remaining quota crosses a warning threshold.
+// Either Command or Webhook must be set.
+type Hook struct {
+	Command []string `json:"command,omitempty"`
+	Webhook string   `json:"webhook,omitempty"`
+}
+
+func (h Hook) Validate() error {
+	if (len(h.Command) == 0) == (h.Webhook == "") {
+		return fmt.Errorf("%w: exactly one of command or webhook is required", ErrInvalidHook)
+	}
+
+	if h.Webhook == "" {
+		return nil
+	}
+
+	u, err := url.Parse(h.Webhook)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidHook, err.Error())
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: webhook scheme must be http or https", ErrInvalidHook)
+	}
+
+	if !isLoopback(u.Hostname()) {
+		return fmt.Errorf("%w: webhook must point to a local address, got %s", ErrInvalidHook, u.Hostname())
+	}
+
+	return nil
+}
+
+func (h Hook) Run(ctx context.Context, w Warning) error {
+	if h.Webhook != "" {
+		return h.post(ctx, w)
+	}
+
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...) // nolint: gosec
+	cmd.Env = append(os.Environ(),
+		"RANDAPI_WARNING_KIND="+string(w.Kind),
+		fmt.Sprintf("RANDAPI_WARNING_LEFT=%d", w.Left),
+		fmt.Sprintf("RANDAPI_WARNING_TOTAL=%d", w.Total),
+		fmt.Sprintf("RANDAPI_WARNING_PERCENT=%.2f", w.Percent),
+		fmt.Sprintf("RANDAPI_WARNING_THRESHOLD=%g", w.Threshold),
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", h.Command[0], err)
+	}
+
+	return nil
+}
+
+func (h Hook) post(ctx context.Context, w Warning) error {
+	body, err := json.Marshal(w)
+	if err != nil {
+		return fmt.Errorf("encode warning: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrUnexpectedReply, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,127 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/ledger"
+)
+
+const (
+	appDir    = "randapi"
+	cacheFile = "limits.json"
+	filePerm  = 0o600
+	dirPerm   = 0o700
+)
+
+// Limits are the total quotas of an api key.
+type Limits struct {
+	TotalRequests uint64    `json:"totalRequests"`
+	TotalBits     uint64    `json:"totalBits"`
+	FetchedAt     time.Time `json:"fetchedAt"`
+}
+
+type UsageFunc func(ctx context.Context, apiKey string) (entities.UsageStatus, error)
+
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("get user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, appDir, cacheFile), nil
+}
+
+func NewLimitsCache(path string, ttl time.Duration, fetch UsageFunc) *LimitsCache {
+	return &LimitsCache{
+		path:  path,
+		ttl:   ttl,
+		fetch: fetch,
+		now:   time.Now,
+	}
+}
+
+// LimitsCache keeps key limits on disk so getUsage is called at most once per ttl.
+type LimitsCache struct {
+	path  string
+	ttl   time.Duration
+	fetch UsageFunc
+	now   func() time.Time
+	mu    sync.Mutex
+}
+
+func (c *LimitsCache) Get(ctx context.Context, apiKey string) (Limits, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		keyHash = ledger.HashKey(apiKey)
+		cached  = c.load()
+	)
+
+	if limits, ok := cached[keyHash]; ok && c.now().Sub(limits.FetchedAt) < c.ttl {
+		return limits, nil
+	}
+
+	usage, err := c.fetch(ctx, apiKey)
+	if err != nil {
+		return Limits{}, fmt.Errorf("get usage: %w", err)
+	}
+
+	limits := Limits{
+		TotalRequests: usage.TotalRequests,
+		TotalBits:     usage.TotalBits,
+		FetchedAt:     c.now().UTC(),
+	}
+
+	cached[keyHash] = limits
+
+	if err := c.store(cached); err != nil {
+		return limits, err
+	}
+
+	return limits, nil
+}
+
+// load returns cached limits. Unreadable cache is treated as empty.
+func (c *LimitsCache) load() map[string]Limits {
+	cached := make(map[string]Limits)
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return cached
+	}
+
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return make(map[string]Limits)
+	}
+
+	return cached
+}
+
+func (c *LimitsCache) store(cached map[string]Limits) error {
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("encode limits cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), dirPerm); err != nil {
+		return fmt.Errorf("create limits cache dir: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, filePerm); err != nil {
+		return fmt.Errorf("write limits cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package quota_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/quota"
+)
+
+func TestLimitsCache(t *testing.T) {
+	var (
+		path  = filepath.Join(t.TempDir(), "cache", "limits.json")
+		calls int
+		fetch = func(_ context.Context, key string) (entities.UsageStatus, error) {
+			require.Equal(t, apiKey, key)
+
+			calls++
+
+			return entities.UsageStatus{TotalRequests: 1000, TotalBits: 250000}, nil
+		}
+	)
+
+	limits, err := quota.NewLimitsCache(path, time.Hour, fetch).Get(context.Background(), apiKey)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), limits.TotalRequests)
+	require.Equal(t, uint64(250000), limits.TotalBits)
+
+	// new cache instance reads limits stored on disk
+	limits, err = quota.NewLimitsCache(path, time.Hour, fetch).Get(context.Background(), apiKey)
+	require.NoError(t, err)
+	require.Equal(t, uint64(250000), limits.TotalBits)
+	require.Equal(t, 1, calls)
+
+	// expired entry is fetched again
+	_, err = quota.NewLimitsCache(path, 0, fetch).Get(context.Background(), apiKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
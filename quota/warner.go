@@ -0,0 +1,164 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	DefaultThreshold = 5.0
+	DefaultLimitsTTL = 24 * time.Hour
+
+	percent = 100
+
+	ErrInvalidThreshold = entities.Error("threshold must be in range (0, 100]")
+)
+
+type Kind string
+
+const (
+	KindRequests Kind = "requests"
+	KindBits     Kind = "bits"
+)
+
+// Warning reports remaining quota at or below a threshold.
+// Crossed is set when this very request moved the quota past the threshold.
+type Warning struct {
+	Kind      Kind    `json:"kind"`
+	Left      uint64  `json:"left"`
+	Total     uint64  `json:"total"`
+	Percent   float64 `json:"percent"`
+	Threshold float64 `json:"threshold"`
+	Crossed   bool    `json:"crossed"`
+}
+
+// Config is the `warnings` section of configuration file.
+type Config struct {
+	Thresholds []float64         `json:"thresholds"`
+	LimitsTTL  entities.Duration `json:"limitsTTL"`
+	Hooks      []Hook            `json:"hooks"`
+}
+
+func (c Config) Validate() error {
+	for _, t := range c.Thresholds {
+		if t <= 0 || t > percent {
+			return fmt.Errorf("%w: %g", ErrInvalidThreshold, t)
+		}
+	}
+
+	for i, h := range c.Hooks {
+		if err := h.Validate(); err != nil {
+			return fmt.Errorf("hooks[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func NewWarner(cfg Config, apiKey string, limits *LimitsCache, timeout time.Duration) *Warner {
+	thresholds := append([]float64(nil), cfg.Thresholds...)
+	if len(thresholds) == 0 {
+		thresholds = []float64{DefaultThreshold}
+	}
+
+	sort.Float64s(thresholds)
+
+	return &Warner{
+		thresholds: thresholds,
+		hooks:      cfg.Hooks,
+		apiKey:     apiKey,
+		limits:     limits,
+		timeout:    timeout,
+	}
+}
+
+type Warner struct {
+	thresholds []float64
+	hooks      []Hook
+	apiKey     string
+	limits     *LimitsCache
+	timeout    time.Duration
+}
+
+func (w *Warner) HasHooks() bool {
+	return len(w.hooks) > 0
+}
+
+// Check compares quota left after request with key limits
+// and runs hooks for every threshold crossed by the request.
+func (w *Warner) Check(apiInfo entities.APIInfo) ([]Warning, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	limits, err := w.limits.Get(ctx, w.apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("key limits: %w", err)
+	}
+
+	var warnings []Warning
+
+	candidates := []struct {
+		kind       Kind
+		left, prev uint64
+		total      uint64
+	}{
+		{KindRequests, apiInfo.RequestsLeft, apiInfo.RequestsLeft + 1, limits.TotalRequests},
+		{KindBits, apiInfo.BitsLeft, apiInfo.BitsLeft + apiInfo.BitsUsed, limits.TotalBits},
+	}
+
+	for _, c := range candidates {
+		if warning, ok := w.evaluate(c.kind, c.left, c.prev, c.total); ok {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	for _, warning := range warnings {
+		if warning.Crossed {
+			w.runHooks(ctx, warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+func (w *Warner) evaluate(kind Kind, left, prev, total uint64) (Warning, bool) {
+	if total == 0 {
+		return Warning{}, false
+	}
+
+	var (
+		current  = percent * float64(left) / float64(total)
+		previous = percent * float64(prev) / float64(total)
+	)
+
+	// thresholds are ascending, so the first reached one is the most severe
+	for _, t := range w.thresholds {
+		if current > t {
+			continue
+		}
+
+		return Warning{
+			Kind:      kind,
+			Left:      left,
+			Total:     total,
+			Percent:   current,
+			Threshold: t,
+			Crossed:   previous > t,
+		}, true
+	}
+
+	return Warning{}, false
+}
+
+func (w *Warner) runHooks(ctx context.Context, warning Warning) {
+	for _, h := range w.hooks {
+		if err := h.Run(ctx, warning); err != nil {
+			log.Printf("WARN: quota hook: %s\n", err)
+		}
+	}
+}
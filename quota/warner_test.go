@@ -0,0 +1,143 @@
+package quota_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/quota"
+)
+
+const apiKey = "c6418ada-7874-4907-9367-f43c446686d3" // nolint: gosec
+
+func staticUsage(totalRequests, totalBits uint64) quota.UsageFunc {
+	return func(context.Context, string) (entities.UsageStatus, error) {
+		return entities.UsageStatus{TotalRequests: totalRequests, TotalBits: totalBits}, nil
+	}
+}
+
+func TestWarnerCheck(t *testing.T) {
+	limits := quota.NewLimitsCache(filepath.Join(t.TempDir(), "limits.json"), time.Hour, staticUsage(200, 10000))
+	warner := quota.NewWarner(quota.Config{Thresholds: []float64{20, 5}}, apiKey, limits, time.Second)
+
+	testcases := []struct {
+		name     string
+		apiInfo  entities.APIInfo
+		expected []quota.Warning
+	}{
+		{
+			name:    "plenty left",
+			apiInfo: entities.APIInfo{RequestsLeft: 150, BitsLeft: 9000, BitsUsed: 10},
+		},
+		{
+			name:    "bits crossed first threshold",
+			apiInfo: entities.APIInfo{RequestsLeft: 150, BitsLeft: 1900, BitsUsed: 200},
+			expected: []quota.Warning{
+				{Kind: quota.KindBits, Left: 1900, Total: 10000, Percent: 19, Threshold: 20, Crossed: true},
+			},
+		},
+		{
+			name:    "bits still below first threshold",
+			apiInfo: entities.APIInfo{RequestsLeft: 150, BitsLeft: 1800, BitsUsed: 50},
+			expected: []quota.Warning{
+				{Kind: quota.KindBits, Left: 1800, Total: 10000, Percent: 18, Threshold: 20},
+			},
+		},
+		{
+			name:    "requests crossed second threshold",
+			apiInfo: entities.APIInfo{RequestsLeft: 10, BitsLeft: 1800, BitsUsed: 50},
+			expected: []quota.Warning{
+				{Kind: quota.KindRequests, Left: 10, Total: 200, Percent: 5, Threshold: 5, Crossed: true},
+				{Kind: quota.KindBits, Left: 1800, Total: 10000, Percent: 18, Threshold: 20},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		warnings, err := warner.Check(tc.apiInfo)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, warnings, tc.name)
+	}
+}
+
+func TestWarnerCheck_Hooks(t *testing.T) {
+	var received []quota.Warning
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var warning quota.Warning
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&warning))
+
+		received = append(received, warning)
+	}))
+
+	defer ts.Close()
+
+	cfg := quota.Config{Hooks: []quota.Hook{{Webhook: ts.URL}}}
+	require.NoError(t, cfg.Validate())
+
+	limits := quota.NewLimitsCache("", time.Hour, staticUsage(1000, 250000))
+	warner := quota.NewWarner(cfg, apiKey, limits, time.Second)
+	require.True(t, warner.HasHooks())
+
+	_, err := warner.Check(entities.APIInfo{RequestsLeft: 50, BitsLeft: 250000})
+	require.NoError(t, err)
+
+	_, err = warner.Check(entities.APIInfo{RequestsLeft: 49, BitsLeft: 250000})
+	require.NoError(t, err)
+
+	require.Equal(t, []quota.Warning{
+		{Kind: quota.KindRequests, Left: 50, Total: 1000, Percent: 5, Threshold: 5, Crossed: true},
+	}, received)
+}
+
+func TestWarnerCheck_UsageFailed(t *testing.T) {
+	limits := quota.NewLimitsCache("", time.Hour, func(context.Context, string) (entities.UsageStatus, error) {
+		return entities.UsageStatus{}, entities.Error("test error")
+	})
+	warner := quota.NewWarner(quota.Config{}, apiKey, limits, time.Second)
+
+	warnings, err := warner.Check(entities.APIInfo{})
+	require.Nil(t, warnings)
+	require.ErrorIs(t, err, entities.Error("test error"))
+}
+
+func TestConfigValidate(t *testing.T) {
+	testcases := []struct {
+		cfg         quota.Config
+		expectedErr string
+	}{
+		{cfg: quota.Config{Thresholds: []float64{0}}, expectedErr: "threshold must be in range (0, 100]: 0"},
+		{cfg: quota.Config{Thresholds: []float64{101}}, expectedErr: "threshold must be in range (0, 100]: 101"},
+		{
+			cfg:         quota.Config{Hooks: []quota.Hook{{}}},
+			expectedErr: "hooks[0]: invalid hook: exactly one of command or webhook is required",
+		},
+		{
+			cfg:         quota.Config{Hooks: []quota.Hook{{Webhook: "http://example.com/hook"}}},
+			expectedErr: "hooks[0]: invalid hook: webhook must point to a local address, got example.com",
+		},
+		{
+			cfg:         quota.Config{Hooks: []quota.Hook{{Webhook: "ftp://localhost/hook"}}},
+			expectedErr: "hooks[0]: invalid hook: webhook scheme must be http or https",
+		},
+		{cfg: quota.Config{Hooks: []quota.Hook{{Webhook: "http://[::1]:8080/hook"}, {Command: []string{"true"}}}}},
+	}
+
+	for _, tc := range testcases {
+		err := tc.cfg.Validate()
+
+		if tc.expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tc.expectedErr)
+		}
+	}
+}
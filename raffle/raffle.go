@@ -0,0 +1,100 @@
+// Package raffle reads raffle participants and records draws in reports
+// that can be checked against the participant file afterwards. Winners are
+// drawn by package choose, weighted by tickets, so a participant wins at
+// most once.
+package raffle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bohdanch-w/rand-api/choose"
+	"github.com/bohdanch-w/rand-api/entities"
+)
+
+const (
+	ErrInvalidParticipant = entities.Error("invalid participant")
+
+	ticketsHeader = "tickets"
+)
+
+// Participants of a raffle read from file.
+type Participants struct {
+	// Entries in file order without duplicates, weight is number of tickets.
+	Entries []choose.Item
+	// Duplicates are names dropped because they appeared before.
+	Duplicates []string
+	// SHA256 is hex encoded hash of the file content.
+	SHA256 string
+}
+
+// ReadParticipants reads NAME[,TICKETS] rows, 1 ticket if omitted. Header row with
+// "tickets" column is skipped. Names are compared case insensitive and only the
+// first entry of a participant counts.
+func ReadParticipants(data []byte) (Participants, error) {
+	sum := sha256.Sum256(data)
+	p := Participants{SHA256: hex.EncodeToString(sum[:])}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	seen := make(map[string]bool)
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return p, nil
+		}
+
+		if err != nil {
+			return Participants{}, fmt.Errorf("read csv: %w", err)
+		}
+
+		name := strings.TrimSpace(record[0])
+		tickets := "1"
+
+		if len(record) > 1 {
+			tickets = strings.TrimSpace(record[1])
+		}
+
+		if row == 1 && strings.EqualFold(tickets, ticketsHeader) {
+			continue
+		}
+
+		entry, err := newEntry(name, tickets)
+		if err != nil {
+			return Participants{}, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		key := strings.ToLower(name)
+		if seen[key] {
+			p.Duplicates = append(p.Duplicates, name)
+
+			continue
+		}
+
+		seen[key] = true
+		p.Entries = append(p.Entries, entry)
+	}
+}
+
+func newEntry(name, tickets string) (choose.Item, error) {
+	if name == "" {
+		return choose.Item{}, fmt.Errorf("%w: empty name", ErrInvalidParticipant)
+	}
+
+	n, err := strconv.Atoi(tickets)
+	if err != nil || n < 1 {
+		return choose.Item{}, fmt.Errorf("%w: %q tickets must be positive integer", ErrInvalidParticipant, name)
+	}
+
+	return choose.Item{Name: name, Weight: n}, nil
+}
@@ -0,0 +1,57 @@
+package raffle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bohdanch-w/rand-api/choose"
+	"github.com/bohdanch-w/rand-api/raffle"
+)
+
+func TestReadParticipants(t *testing.T) {
+	p, err := raffle.ReadParticipants([]byte("name,tickets\nalice,3\nbob\n ALICE ,5\ncarol, 2\nBob,1\n"))
+	require.NoError(t, err)
+	require.Equal(t, raffle.Participants{
+		Entries: []choose.Item{
+			{Name: "alice", Weight: 3},
+			{Name: "bob", Weight: 1},
+			{Name: "carol", Weight: 2},
+		},
+		Duplicates: []string{"ALICE", "Bob"},
+		SHA256:     "ccdd7ad726490a56be805f79a94e61d7ca30fcb0266451ca8dc54e7d9372c85f",
+	}, p)
+}
+
+func TestReadParticipants_Invalid(t *testing.T) {
+	_, err := raffle.ReadParticipants([]byte("alice,3\nbob,0\n"))
+	require.EqualError(t, err, `row 2: invalid participant: "bob" tickets must be positive integer`)
+
+	_, err = raffle.ReadParticipants([]byte("alice\n,2\n"))
+	require.EqualError(t, err, "row 2: invalid participant: empty name")
+}
+
+func TestNewReport(t *testing.T) {
+	p := raffle.Participants{
+		Entries: []choose.Item{{Name: "alice", Weight: 3}, {Name: "bob", Weight: 1}},
+		SHA256:  "abc",
+	}
+
+	calls := []raffle.Call{{ID: uuid.MustParse("71d996a7-ff3f-4ba1-84bb-f4cad27eafb6"), Values: []int{5}}}
+	drawnAt := time.Date(2026, 1, 31, 18, 0, 0, 0, time.FixedZone("EET", 2*60*60))
+
+	r := raffle.NewReport("participants.csv", p, choose.Result{Items: p.Entries, Winners: []int{1}}, calls, drawnAt)
+	require.Equal(t, raffle.Report{
+		File:         "participants.csv",
+		SHA256:       "abc",
+		Participants: 2,
+		Tickets:      4,
+		Duplicates:   []string{},
+		RangeMax:     choose.RangeMax,
+		Calls:        calls,
+		Winners:      []raffle.Winner{{Name: "bob", Index: 1, Tickets: 1}},
+		DrawnAt:      time.Date(2026, 1, 31, 16, 0, 0, 0, time.UTC),
+	}, r)
+}
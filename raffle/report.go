@@ -0,0 +1,97 @@
+package raffle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bohdanch-w/rand-api/choose"
+)
+
+const reportPerm = 0o600
+
+// Call is a random.org call made by the draw.
+type Call struct {
+	ID             uuid.UUID `json:"id"`
+	CompletionTime time.Time `json:"completionTime"`
+	Source         string    `json:"source"`
+	// Values are integers in [0, rangeMax) in order of use.
+	Values []int `json:"values"`
+}
+
+type Winner struct {
+	Name string `json:"name"`
+	// Index is 0-based position of participant after duplicates are dropped.
+	Index   int `json:"index"`
+	Tickets int `json:"tickets"`
+}
+
+// Report is audit record of a raffle draw.
+type Report struct {
+	File         string    `json:"file"`
+	SHA256       string    `json:"sha256"`
+	Participants int       `json:"participants"`
+	Tickets      int       `json:"tickets"`
+	Duplicates   []string  `json:"duplicates"`
+	RangeMax     int       `json:"rangeMax"`
+	Calls        []Call    `json:"calls"`
+	Winners      []Winner  `json:"winners"`
+	DrawnAt      time.Time `json:"drawnAt"`
+}
+
+func NewReport(file string, p Participants, res choose.Result, calls []Call, drawnAt time.Time) Report {
+	r := Report{
+		File:         file,
+		SHA256:       p.SHA256,
+		Participants: len(p.Entries),
+		Tickets:      choose.TotalWeight(p.Entries),
+		Duplicates:   p.Duplicates,
+		RangeMax:     choose.RangeMax,
+		Calls:        calls,
+		Winners:      make([]Winner, 0, len(res.Winners)),
+		DrawnAt:      drawnAt.UTC(),
+	}
+
+	if r.Duplicates == nil {
+		r.Duplicates = []string{}
+	}
+
+	for _, i := range res.Winners {
+		r.Winners = append(r.Winners, Winner{Name: p.Entries[i].Name, Index: i, Tickets: p.Entries[i].Weight})
+	}
+
+	return r
+}
+
+// CreateReport creates report file before the draw, so values aren't spent on a
+// draw whose report can't be saved. Existing file is kept unless overwrite is set.
+func CreateReport(path string, overwrite bool) (*os.File, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(path, flags, reportPerm)
+	if err != nil {
+		return nil, fmt.Errorf("create report: %w", err)
+	}
+
+	return f, nil
+}
+
+func (r Report) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
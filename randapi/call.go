@@ -0,0 +1,67 @@
+package randapi
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/bohdanch-w/rand-api/randapi"
+
+// call instruments a single JSON-RPC call with span and observer events.
+type call struct {
+	observer Observer
+	method   string
+	start    time.Time
+	span     trace.Span
+}
+
+func (svc *RandomOrgRetriever) startCall(ctx context.Context, method, requestID string) (context.Context, *call) {
+	ctx, span := otel.Tracer(instrumentation).Start(ctx, "random.org "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.method", method),
+			attribute.String("randapi.request_id", requestID),
+		),
+	)
+
+	return ctx, &call{
+		observer: svc.observer,
+		method:   method,
+		start:    time.Now(),
+		span:     span,
+	}
+}
+
+func (c *call) bitsUsed(bits uint64) {
+	c.observer.ObserveBitsUsed(c.method, bits)
+	c.span.SetAttributes(attribute.Int64("randapi.bits_used", int64(bits)))
+}
+
+func (c *call) remaining(bitsLeft, requestsLeft uint64) {
+	c.observer.ObserveRemaining(bitsLeft, requestsLeft)
+	c.span.SetAttributes(
+		attribute.Int64("randapi.bits_left", int64(bitsLeft)),
+		attribute.Int64("randapi.requests_left", int64(requestsLeft)),
+	)
+}
+
+// end finishes the call. Err is returned unchanged for convenience.
+func (c *call) end(outcome Outcome, err error) error {
+	c.observer.ObserveRequest(c.method, outcome, time.Since(c.start))
+	c.span.SetAttributes(attribute.String("randapi.outcome", string(outcome)))
+
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	}
+
+	c.span.End()
+
+	return err
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
+	"time"
 
 	"github.com/bohdanch-w/rand-api/entities"
 	"github.com/bohdanch-w/rand-api/services"
@@ -15,11 +19,20 @@ import (
 
 const jsonRPCVersion = "2.0"
 
+// SourceName marks results produced by random.org.
+const SourceName = "randomorg"
+
+// DefaultAPIPath is random.org JSON-RPC 4 endpoint.
+const DefaultAPIPath = "https://api.random.org/json-rpc/4/invoke"
+
 const (
 	ErrUnexpectedStatusCode     = entities.Error("unexpected status code")
 	ErrRequestResponseMissmatch = entities.Error("request and response id mismatch")
 	ErrUnexpectedJSONRPSVersion = entities.Error("unexpected json rpc version")
 	ErrErrorInResponse          = entities.Error("error in response")
+	ErrUnreachable              = entities.Error("random.org is unreachable")
+	// ErrNotRecorded is returned by replaying transports that have no response for a request.
+	ErrNotRecorded = entities.Error("no recorded interaction")
 )
 
 var _ services.RandRetiever = (*RandomOrgRetriever)(nil)
@@ -29,6 +42,7 @@ func NewRandomOrgRetriever(randAPIPath string, client *http.Client, signed bool)
 		apiPath:        randAPIPath,
 		jsonRPCVersion: jsonRPCVersion,
 		client:         client,
+		observer:       nopObserver{},
 	}
 }
 
@@ -36,9 +50,17 @@ type RandomOrgRetriever struct {
 	apiPath        string
 	jsonRPCVersion string
 	client         *http.Client
+	observer       Observer
+
+	mu          sync.Mutex
+	nextRequest time.Time
 }
 
-func (svc *RandomOrgRetriever) ExecuteRequest( // nolint: funlen
+func (svc *RandomOrgRetriever) SetObserver(o Observer) {
+	svc.observer = o
+}
+
+func (svc *RandomOrgRetriever) ExecuteRequest(
 	ctx context.Context,
 	randReq *entities.RandomRequest,
 ) (entities.RandResponseResult, error) {
@@ -54,43 +76,131 @@ func (svc *RandomOrgRetriever) ExecuteRequest( // nolint: funlen
 		return result, fmt.Errorf("encode payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, svc.apiPath, buf)
+	ctx, c := svc.startCall(ctx, randReq.Method, randReq.ID.String())
+
+	data, outcome, err := svc.post(ctx, buf.Bytes())
 	if err != nil {
-		return result, fmt.Errorf("create request: %w", err)
+		return result, c.end(outcome, err)
+	}
+
+	var randResp randResponse
+
+	if err := randResp.parse(data); err != nil {
+		return result, c.end(parseOutcome(err), fmt.Errorf("invalid response: %w", err))
+	}
+
+	if randResp.ID != randReq.ID {
+		err := fmt.Errorf("%w: %s != %s", ErrRequestResponseMissmatch, randResp.ID.String(), randReq.ID.String())
+
+		return result, c.end(OutcomeInvalidResponse, err)
+	}
+
+	result = *randResp.Result
+	result.Source = SourceName
+
+	c.bitsUsed(result.BitsUsed)
+	c.remaining(result.BitsLeft, result.RequestsLeft)
+	_ = c.end(OutcomeSuccess, nil)
+
+	svc.delayNext(result.AdvisoryDelay)
+
+	return result, nil
+}
+
+// post sends payload honoring advisory delay of the previous call. Failed calls
+// are not retried: random.org may have spent quota on a call whose response was
+// lost. Outcome describes the failure when error is returned.
+func (svc *RandomOrgRetriever) post(ctx context.Context, payload []byte) ([]byte, Outcome, error) {
+	if err := svc.waitAdvisoryDelay(ctx); err != nil {
+		return nil, OutcomeNetworkError, unreachable(OutcomeNetworkError, err)
+	}
+
+	data, outcome, err := svc.postOnce(ctx, payload)
+
+	return data, outcome, unreachable(outcome, err)
+}
+
+func (svc *RandomOrgRetriever) postOnce(ctx context.Context, payload []byte) ([]byte, Outcome, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, svc.apiPath, bytes.NewReader(payload))
+	if err != nil {
+		return nil, OutcomeNetworkError, fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "rand-api/0.1")
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := svc.client.Do(req)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && errors.Is(urlErr.Err, ErrNotRecorded) {
+		// nothing was sent, so fallback doesn't apply
+		return nil, OutcomeNotRecorded, urlErr.Err
+	}
+
 	if err != nil {
-		return result, fmt.Errorf("execute request: %w", err)
+		return nil, OutcomeNetworkError, fmt.Errorf("execute request: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return result, fmt.Errorf("read body: %w", err)
+		return nil, OutcomeNetworkError, fmt.Errorf("read body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+		return nil, OutcomeHTTPError, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
 	}
 
-	var randResp randResponse
+	return data, OutcomeSuccess, nil
+}
 
-	if err := randResp.parse(data); err != nil {
-		return result, fmt.Errorf("invalid response: %w", err)
+func (svc *RandomOrgRetriever) waitAdvisoryDelay(ctx context.Context) error {
+	svc.mu.Lock()
+	wait := time.Until(svc.nextRequest)
+	svc.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
 	}
 
-	if randResp.ID != randReq.ID {
-		return result, fmt.Errorf("%w: %s != %s", ErrRequestResponseMissmatch, randResp.ID.String(), randReq.ID.String())
+	svc.observer.ObserveAdvisoryWait(wait)
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait advisory delay: %w", ctx.Err())
+	case <-time.After(wait):
+		return nil
 	}
+}
 
-	result = *randResp.Result
+// delayNext remembers random.org advisory delay (in milliseconds) before the next call.
+func (svc *RandomOrgRetriever) delayNext(advisoryDelay uint64) {
+	if advisoryDelay == 0 {
+		return
+	}
 
-	return result, nil
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	svc.nextRequest = time.Now().Add(time.Duration(advisoryDelay) * time.Millisecond)
+}
+
+// unreachable marks network failures and timeouts, so callers can tell them
+// from requests random.org has rejected.
+func unreachable(outcome Outcome, err error) error {
+	if err == nil || outcome != OutcomeNetworkError {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrUnreachable, err)
+}
+
+func parseOutcome(err error) Outcome {
+	if errors.Is(err, ErrErrorInResponse) {
+		return OutcomeRPCError
+	}
+
+	return OutcomeInvalidResponse
 }
 
 type randResponse struct {
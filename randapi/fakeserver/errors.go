@@ -0,0 +1,64 @@
+package fakeserver
+
+import "fmt"
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternal       = -32603
+
+	codeMalformed        = 200
+	codeOutOfRange       = 202
+	codeMinAboveMax      = 300
+	codeNotEnoughValues  = 301
+	codeUnknownKey       = 400
+	codeStoppedKey       = 401
+	codeRequestsExceeded = 402
+	codeBitsExceeded     = 403
+	codeUnknownTicket    = 420
+	codeForeignTicket    = 421
+	codeUsedTicket       = 422
+	codeUnknownSerial    = 423
+)
+
+type rpcError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []interface{} `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func newError(code int, message string, data ...interface{}) *rpcError {
+	return &rpcError{Code: code, Message: message, Data: data}
+}
+
+func errParse() *rpcError {
+	return newError(codeParseError, "Parse error")
+}
+
+func errInvalidRequest() *rpcError {
+	return newError(codeInvalidRequest, "Invalid Request")
+}
+
+func errMethodNotFound(method string) *rpcError {
+	return newError(codeMethodNotFound, fmt.Sprintf("Method '%s' not found", method), method)
+}
+
+func errMissing(name string) *rpcError {
+	return newError(codeInvalidParams, fmt.Sprintf("Invalid params: missing parameter '%s'", name), name)
+}
+
+func errMalformed(name string) *rpcError {
+	return newError(codeMalformed, fmt.Sprintf("Parameter '%s' is malformed", name), name)
+}
+
+func errOutOfRange(name string, min, max interface{}) *rpcError {
+	return newError(codeOutOfRange,
+		fmt.Sprintf("Parameter '%s' is out of range. Allowable values are [%v, %v]", name, min, max),
+		name, min, max)
+}
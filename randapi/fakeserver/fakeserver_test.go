@@ -0,0 +1,398 @@
+package fakeserver_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/randapi/fakeserver"
+)
+
+const apiKey = "6b81b415-80e9-4481-a5f1-58e354742c00" //nolint: gosec
+
+var signingKey = sync.OnceValue(func() *rsa.PrivateKey { // nolint: gochecknoglobals
+	key, err := rsa.GenerateKey(rand.Reader, 1024) // nolint: gosec
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+})
+
+func newServer(t *testing.T, opts fakeserver.Options) (*fakeserver.Server, string) {
+	t.Helper()
+
+	opts.SigningKey = signingKey()
+
+	fs, err := fakeserver.New(opts)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(fs)
+	t.Cleanup(ts.Close)
+
+	return fs, ts.URL
+}
+
+func call(t *testing.T, url, method string, params map[string]interface{}) gjson.Result {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      42,
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(payload)) // nolint: noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	res := gjson.ParseBytes(body)
+	require.Equal(t, "2.0", res.Get("jsonrpc").String())
+	require.Equal(t, int64(42), res.Get("id").Int())
+
+	return res
+}
+
+func TestRetriever(t *testing.T) {
+	fs, url := newServer(t, fakeserver.Options{
+		Keys:          []fakeserver.Key{{APIKey: apiKey, BitsLeft: 1000, RequestsLeft: 10}},
+		AdvisoryDelay: time.Millisecond,
+	})
+
+	svc := randapi.NewRandomOrgRetriever(url, http.DefaultClient, false)
+
+	req, err := svc.NewRequest("generateIntegers", map[string]interface{}{
+		"apiKey": apiKey, "n": 5, "min": 1, "max": 6, "replacement": true,
+	})
+	require.NoError(t, err)
+
+	result, err := svc.ExecuteRequest(context.Background(), &req)
+	require.NoError(t, err)
+
+	var values []int
+
+	require.NoError(t, json.Unmarshal(result.Random.Data, &values))
+	require.Len(t, values, 5)
+
+	for _, v := range values {
+		require.True(t, v >= 1 && v <= 6, v)
+	}
+
+	require.Equal(t, uint64(13), result.BitsUsed)
+	require.Equal(t, uint64(987), result.BitsLeft)
+	require.Equal(t, uint64(9), result.RequestsLeft)
+	require.Equal(t, uint64(1), result.AdvisoryDelay)
+
+	usage, err := svc.GetUsage(context.Background(), apiKey)
+	require.NoError(t, err)
+	require.Equal(t, uint64(987), usage.BitsLeft)
+	require.Equal(t, uint64(9), usage.RequestsLeft)
+
+	bits, requests, ok := fs.Usage(apiKey)
+	require.True(t, ok)
+	require.Equal(t, int64(987), bits)
+	require.Equal(t, int64(9), requests)
+}
+
+func TestGenerate(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{})
+
+	testCases := []struct {
+		name   string
+		method string
+		params map[string]interface{}
+		check  func(t *testing.T, data gjson.Result)
+	}{
+		{
+			name:   "integers in base 16",
+			method: "generateIntegers",
+			params: map[string]interface{}{"n": 3, "min": 0, "max": 255, "base": 16},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array(), 3)
+				require.Equal(t, gjson.String, data.Array()[0].Type)
+			},
+		},
+		{
+			name:   "integer sequences",
+			method: "generateIntegerSequences",
+			params: map[string]interface{}{
+				"n": 2, "length": []int{5, 1}, "min": 1, "max": []int{50, 12}, "replacement": false,
+			},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array(), 2)
+				require.Len(t, data.Array()[0].Array(), 5)
+				require.Len(t, data.Array()[1].Array(), 1)
+			},
+		},
+		{
+			name:   "decimal fractions",
+			method: "generateDecimalFractions",
+			params: map[string]interface{}{"n": 4, "decimalPlaces": 2},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array(), 4)
+			},
+		},
+		{
+			name:   "gaussians",
+			method: "generateGaussians",
+			params: map[string]interface{}{"n": 2, "mean": 10, "standardDeviation": 1, "significantDigits": 5},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array(), 2)
+			},
+		},
+		{
+			name:   "strings",
+			method: "generateStrings",
+			params: map[string]interface{}{"n": 2, "length": 6, "characters": "ab"},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Regexp(t, "^[ab]{6}$", data.Array()[1].String())
+			},
+		},
+		{
+			name:   "uuids",
+			method: "generateUUIDs",
+			params: map[string]interface{}{"n": 1},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array()[0].String(), 36)
+			},
+		},
+		{
+			name:   "blobs in hex",
+			method: "generateBlobs",
+			params: map[string]interface{}{"n": 1, "size": 64, "format": "hex"},
+			check: func(t *testing.T, data gjson.Result) {
+				require.Len(t, data.Array()[0].String(), 16)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params["apiKey"] = apiKey
+
+			res := call(t, url, tc.method, tc.params)
+			require.False(t, res.Get("error").Exists(), res.Raw)
+			require.NotEmpty(t, res.Get("result.random.completionTime").String())
+
+			tc.check(t, res.Get("result.random.data"))
+		})
+	}
+}
+
+func TestPregeneratedRandomization(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{})
+
+	params := map[string]interface{}{
+		"apiKey": apiKey, "n": 10, "min": 1, "max": 1000,
+		"pregeneratedRandomization": map[string]string{"id": "draw"},
+	}
+
+	first := call(t, url, "generateIntegers", params).Get("result.random.data").Raw
+	second := call(t, url, "generateIntegers", params).Get("result.random.data").Raw
+
+	require.Equal(t, first, second)
+}
+
+func TestErrors(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{Keys: []fakeserver.Key{
+		{APIKey: apiKey, BitsLeft: 20, RequestsLeft: 3},
+		{APIKey: "stopped", BitsLeft: 1000, RequestsLeft: 1000, Stopped: true},
+		{APIKey: "no-requests", BitsLeft: 1000},
+	}})
+
+	testCases := []struct {
+		name   string
+		method string
+		params map[string]interface{}
+		code   int64
+	}{
+		{
+			name:   "unknown method",
+			method: "generateColors",
+			params: map[string]interface{}{"apiKey": apiKey},
+			code:   -32601,
+		},
+		{
+			name:   "missing param",
+			method: "generateIntegers",
+			params: map[string]interface{}{"apiKey": apiKey, "n": 1, "min": 1},
+			code:   -32602,
+		},
+		{
+			name:   "malformed param",
+			method: "generateIntegers",
+			params: map[string]interface{}{"apiKey": apiKey, "n": "one", "min": 1, "max": 2},
+			code:   200,
+		},
+		{
+			name:   "out of range",
+			method: "generateIntegers",
+			params: map[string]interface{}{"apiKey": apiKey, "n": 10001, "min": 1, "max": 2},
+			code:   202,
+		},
+		{
+			name:   "min above max",
+			method: "generateIntegers",
+			params: map[string]interface{}{"apiKey": apiKey, "n": 1, "min": 3, "max": 2},
+			code:   300,
+		},
+		{
+			name:   "not enough values",
+			method: "generateIntegers",
+			params: map[string]interface{}{"apiKey": apiKey, "n": 3, "min": 1, "max": 2, "replacement": false},
+			code:   301,
+		},
+		{
+			name:   "unknown key",
+			method: "generateUUIDs",
+			params: map[string]interface{}{"apiKey": "unknown", "n": 1},
+			code:   400,
+		},
+		{
+			name:   "stopped key",
+			method: "generateUUIDs",
+			params: map[string]interface{}{"apiKey": "stopped", "n": 1},
+			code:   401,
+		},
+		{
+			name:   "requests exceeded",
+			method: "generateUUIDs",
+			params: map[string]interface{}{"apiKey": "no-requests", "n": 1},
+			code:   402,
+		},
+		{
+			name:   "bits exceeded",
+			method: "generateUUIDs",
+			params: map[string]interface{}{"apiKey": apiKey, "n": 1},
+			code:   403,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			res := call(t, url, tc.method, tc.params)
+			require.False(t, res.Get("result").Exists(), res.Raw)
+			require.Equal(t, tc.code, res.Get("error.code").Int(), res.Raw)
+			require.NotEmpty(t, res.Get("error.message").String())
+		})
+	}
+
+	// rejected requests don't spend quota
+	res := call(t, url, "getUsage", map[string]interface{}{"apiKey": apiKey})
+	require.Equal(t, int64(20), res.Get("result.bitsLeft").Int())
+	require.Equal(t, int64(3), res.Get("result.requestsLeft").Int())
+}
+
+func TestErrors_Protocol(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{})
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader([]byte("{"))) // nolint: noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, int64(-32700), gjson.GetBytes(body, "error.code").Int())
+
+	resp, err = http.Get(url) // nolint: noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestSigned(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{})
+
+	res := call(t, url, "generateSignedIntegers", map[string]interface{}{
+		"apiKey": apiKey, "n": 6, "min": 1, "max": 49, "replacement": false,
+		"userData": map[string]string{"draw": "weekly"},
+	})
+	require.False(t, res.Get("error").Exists(), res.Raw)
+
+	random := res.Get("result.random")
+	require.Equal(t, "generateSignedIntegers", random.Get("method").String())
+	require.Equal(t, int64(6), random.Get("n").Int())
+	require.Equal(t, "weekly", random.Get("userData.draw").String())
+	require.Len(t, random.Get("data").Array(), 6)
+
+	serial := random.Get("serialNumber").Int()
+	require.Equal(t, int64(1), serial)
+
+	verify := func(random string) bool {
+		res := call(t, url, "verifySignature", map[string]interface{}{
+			"random":    json.RawMessage(random),
+			"signature": res.Get("result.signature").String(),
+		})
+		require.False(t, res.Get("error").Exists(), res.Raw)
+
+		return res.Get("result.authenticity").Bool()
+	}
+
+	require.True(t, verify(random.Raw))
+	require.False(t, verify(`{"data":[1,2,3,4,5,6]}`))
+
+	stored := call(t, url, "getResult", map[string]interface{}{"apiKey": apiKey, "serialNumber": serial})
+	require.JSONEq(t, random.Raw, stored.Get("result.random").Raw)
+
+	foreign := call(t, url, "getResult", map[string]interface{}{"apiKey": "another", "serialNumber": serial})
+	require.Equal(t, int64(423), foreign.Get("error.code").Int())
+}
+
+func TestTickets(t *testing.T) {
+	_, url := newServer(t, fakeserver.Options{})
+
+	created := call(t, url, "createTickets", map[string]interface{}{"apiKey": apiKey, "n": 2, "showResult": true})
+	require.Len(t, created.Get("result").Array(), 2)
+
+	ticketID := created.Get("result.0.ticketId").String()
+	require.NotEmpty(t, ticketID)
+	require.Equal(t, gjson.Null, created.Get("result.0.usedTime").Type)
+
+	params := map[string]interface{}{"apiKey": apiKey, "n": 1, "ticketId": ticketID}
+
+	used := call(t, url, "generateSignedUUIDs", params)
+	require.False(t, used.Get("error").Exists(), used.Raw)
+	require.Equal(t, ticketID, used.Get("result.random.ticketData.ticketId").String())
+
+	require.Equal(t, int64(422), call(t, url, "generateSignedUUIDs", params).Get("error.code").Int())
+
+	params["apiKey"] = "another"
+	require.Equal(t, int64(421), call(t, url, "generateSignedUUIDs", params).Get("error.code").Int())
+
+	params["ticketId"] = "missing"
+	require.Equal(t, int64(420), call(t, url, "generateSignedUUIDs", params).Get("error.code").Int())
+
+	ticket := call(t, url, "getTicket", map[string]interface{}{"ticketId": ticketID})
+	require.NotEmpty(t, ticket.Get("result.usedTime").String())
+	require.Equal(t, used.Get("result.random.serialNumber").Int(), ticket.Get("result.serialNumber").Int())
+	require.JSONEq(t, used.Get("result.random").Raw, ticket.Get("result.result.random").Raw)
+
+	list := call(t, url, "listTickets", map[string]interface{}{"apiKey": apiKey, "ticketType": "singleton"})
+	require.Len(t, list.Get("result").Array(), 2)
+}
@@ -0,0 +1,547 @@
+package fakeserver
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	mrand "math/rand/v2"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bohdanch-w/rand-api/pkg/derive"
+)
+
+const (
+	valueMax        = 1_000_000_000
+	numberMax       = 10_000
+	gaussianMax     = 1_000_000
+	uuidsMax        = 1_000
+	blobsMax        = 100
+	blobSizeMax     = 1_048_576
+	stringLengthMax = 32
+	charactersMax   = 128
+	placesMax       = 14
+	digitsMin       = 2
+	uuidBits        = 122
+	decimalBase     = 10
+)
+
+// generation is validated request of a generate method.
+type generation struct {
+	// fields are validated params echoed in signed random object.
+	fields map[string]interface{}
+	bits   int64
+	derive func(r io.Reader) (interface{}, error)
+}
+
+type generator func(p params) (*generation, *rpcError)
+
+// generators are keyed by method name without generate/generateSigned prefix.
+var generators = map[string]generator{ // nolint: gochecknoglobals
+	"Integers":         integers,
+	"IntegerSequences": integerSequences,
+	"DecimalFractions": decimalFractions,
+	"Gaussians":        gaussians,
+	"Strings":          strs,
+	"UUIDs":            uuids,
+	"Blobs":            blobs,
+}
+
+func (s *Server) generate(name string, gen generator, signed bool) handler {
+	return func(req *request) (interface{}, *rpcError) {
+		g, rpcErr := gen(req.params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		r, rpcErr := s.reader(name, req.params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		var extra signedParams
+
+		if signed {
+			if extra, rpcErr = parseSignedParams(req.params); rpcErr != nil {
+				return nil, rpcErr
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		k, rpcErr := s.key(req.params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		var t *ticket
+
+		if signed && extra.ticketID != "" {
+			if t, rpcErr = s.ticketToUse(extra.ticketID, k.APIKey); rpcErr != nil {
+				return nil, rpcErr
+			}
+		}
+
+		if rpcErr := k.charge(g.bits); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		data, err := g.derive(r)
+		if err != nil {
+			return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+		}
+
+		completion := formatTime(s.opts.Now())
+
+		if !signed {
+			return s.result(k, g.bits, map[string]interface{}{
+				"data":           data,
+				"completionTime": completion,
+			}), nil
+		}
+
+		return s.sign(req.method, k, g, data, completion, extra, t)
+	}
+}
+
+func (s *Server) result(k *keyState, bits int64, random interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"random":        random,
+		"bitsUsed":      bits,
+		"bitsLeft":      k.BitsLeft,
+		"requestsLeft":  k.RequestsLeft,
+		"advisoryDelay": s.opts.AdvisoryDelay.Milliseconds(),
+	}
+}
+
+// reader returns server source, or stream determined by pregenerated
+// randomization, so the same randomization and params give the same values.
+func (s *Server) reader(name string, p params) (io.Reader, *rpcError) {
+	const param = "pregeneratedRandomization"
+
+	if !p.has(param) {
+		return s.opts.Rand, nil
+	}
+
+	var (
+		key string
+		obj struct {
+			Date *string `json:"date"`
+			ID   *string `json:"id"`
+		}
+	)
+
+	switch {
+	case json.Unmarshal(p[param], &key) == nil:
+	case json.Unmarshal(p[param], &obj) == nil && (obj.Date == nil) != (obj.ID == nil):
+		if obj.Date != nil {
+			key = "date:" + *obj.Date
+		} else {
+			key = "id:" + *obj.ID
+		}
+	default:
+		return nil, errMalformed(param)
+	}
+
+	return mrand.NewChaCha8(sha256.Sum256([]byte(name + "/" + key))), nil
+}
+
+func integers(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	seq, rpcErr := sequenceParams(p, 1, []int64{n})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return &generation{
+		fields: map[string]interface{}{
+			"n": n, "min": seq.min[0], "max": seq.max[0], "replacement": seq.replacement[0], "base": seq.base[0],
+		},
+		bits: seq.bits(),
+		derive: func(r io.Reader) (interface{}, error) {
+			values, err := seq.derive(r)
+			if err != nil {
+				return nil, err
+			}
+
+			return values[0], nil
+		},
+	}, nil
+}
+
+func integerSequences(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	length, rpcErr := intList(p, "length", int(n), nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	var total int64
+
+	for _, l := range length {
+		total += l
+	}
+
+	if total > numberMax {
+		return nil, errOutOfRange("length", 1, numberMax)
+	}
+
+	seq, rpcErr := sequenceParams(p, int(n), length)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return &generation{
+		fields: map[string]interface{}{
+			"n": n, "length": p["length"], "min": p["min"], "max": p["max"],
+			"replacement": seq.replacement, "base": seq.base,
+		},
+		bits: seq.bits(),
+		derive: func(r io.Reader) (interface{}, error) {
+			return seq.derive(r)
+		},
+	}, nil
+}
+
+func decimalFractions(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	places, rpcErr := p.int("decimalPlaces", nil, 1, placesMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	replacement, rpcErr := p.bool("replacement", true)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if domain := math.Pow10(int(places)); !replacement && float64(n) > domain {
+		return nil, errNotEnoughValues(n, domain)
+	}
+
+	return &generation{
+		fields: map[string]interface{}{"n": n, "decimalPlaces": places, "replacement": replacement},
+		bits:   bitsFor(n, math.Pow10(int(places))),
+		derive: func(r io.Reader) (interface{}, error) {
+			return derive.Decimals(r, int(n), int(places), replacement) // nolint: wrapcheck
+		},
+	}, nil
+}
+
+func gaussians(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	mean, rpcErr := p.float("mean", -gaussianMax, gaussianMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	deviation, rpcErr := p.float("standardDeviation", -gaussianMax, gaussianMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	digits, rpcErr := p.int("significantDigits", nil, digitsMin, placesMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return &generation{
+		fields: map[string]interface{}{
+			"n": n, "mean": mean, "standardDeviation": deviation, "significantDigits": digits,
+		},
+		bits: bitsFor(n, math.Pow10(int(digits))),
+		derive: func(r io.Reader) (interface{}, error) {
+			return derive.Gaussians(r, int(n), mean, deviation, int(digits)) // nolint: wrapcheck
+		},
+	}, nil
+}
+
+func strs(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, numberMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	length, rpcErr := p.int("length", nil, 1, stringLengthMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	characters, rpcErr := p.str("characters", nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	chars := utf8.RuneCountInString(characters)
+	if chars < 1 || chars > charactersMax {
+		return nil, errOutOfRange("characters", 1, charactersMax)
+	}
+
+	replacement, rpcErr := p.bool("replacement", true)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if domain := math.Pow(float64(chars), float64(length)); !replacement && float64(n) > domain {
+		return nil, errNotEnoughValues(n, domain)
+	}
+
+	return &generation{
+		fields: map[string]interface{}{
+			"n": n, "length": length, "characters": characters, "replacement": replacement,
+		},
+		bits: bitsFor(n*length, float64(chars)),
+		derive: func(r io.Reader) (interface{}, error) {
+			return derive.Strings(r, int(n), int(length), characters, replacement) // nolint: wrapcheck
+		},
+	}, nil
+}
+
+func uuids(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, uuidsMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return &generation{
+		fields: map[string]interface{}{"n": n},
+		bits:   n * uuidBits,
+		derive: func(r io.Reader) (interface{}, error) {
+			return derive.UUIDs(r, int(n)) // nolint: wrapcheck
+		},
+	}, nil
+}
+
+func blobs(p params) (*generation, *rpcError) {
+	n, rpcErr := p.int("n", nil, 1, blobsMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	size, rpcErr := p.int("size", nil, 1, blobSizeMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if size%8 != 0 {
+		return nil, newError(codeMalformed, "Parameter 'size' must be divisible by 8", "size")
+	}
+
+	if n*size > blobSizeMax {
+		return nil, errOutOfRange("size", 1, blobSizeMax/n)
+	}
+
+	format, rpcErr := p.str("format", ptr("base64"))
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	var encode func([]byte) string
+
+	switch format {
+	case "base64":
+		encode = base64.StdEncoding.EncodeToString
+	case "hex":
+		encode = hex.EncodeToString
+	default:
+		return nil, errMalformed("format")
+	}
+
+	return &generation{
+		fields: map[string]interface{}{"n": n, "size": size, "format": format},
+		bits:   n * size,
+		derive: func(r io.Reader) (interface{}, error) {
+			values, err := derive.Blobs(r, int(n), int(size/8)) // nolint: gomnd
+			if err != nil {
+				return nil, err // nolint: wrapcheck
+			}
+
+			res := make([]string, 0, len(values))
+
+			for _, v := range values {
+				res = append(res, encode(v))
+			}
+
+			return res, nil
+		},
+	}, nil
+}
+
+// sequences are validated params of integer sequences, one entry per sequence.
+type sequences struct {
+	length      []int64
+	min, max    []int64
+	replacement []bool
+	base        []int64
+}
+
+func sequenceParams(p params, n int, length []int64) (*sequences, *rpcError) {
+	var (
+		seq    = &sequences{length: length}
+		rpcErr *rpcError
+	)
+
+	if seq.min, rpcErr = intList(p, "min", n, nil, -valueMax, valueMax); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if seq.max, rpcErr = intList(p, "max", n, nil, -valueMax, valueMax); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if seq.base, rpcErr = intList(p, "base", n, ptr(int64(decimalBase)), 2, 16); rpcErr != nil { // nolint: gomnd
+		return nil, rpcErr
+	}
+
+	if seq.replacement, rpcErr = boolList(p, "replacement", n, true); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	for i := range seq.min {
+		switch b := seq.base[i]; b {
+		case 2, 8, 10, 16: // nolint: gomnd
+		default:
+			return nil, newError(codeMalformed, "Parameter 'base' must be one of 2, 8, 10 or 16", "base")
+		}
+
+		if seq.min[i] > seq.max[i] {
+			return nil, newError(codeMinAboveMax, "Parameter 'min' must be less than or equal to parameter 'max'",
+				"min", "max")
+		}
+
+		if domain := seq.max[i] - seq.min[i] + 1; !seq.replacement[i] && seq.length[i] > domain {
+			return nil, errNotEnoughValues(seq.length[i], float64(domain))
+		}
+	}
+
+	return seq, nil
+}
+
+func (seq *sequences) bits() int64 {
+	var bits int64
+
+	for i := range seq.length {
+		bits += bitsFor(seq.length[i], float64(seq.max[i]-seq.min[i]+1))
+	}
+
+	return bits
+}
+
+func (seq *sequences) derive(r io.Reader) ([]interface{}, error) {
+	res := make([]interface{}, 0, len(seq.length))
+
+	for i := range seq.length {
+		values, err := derive.Integers(r, int(seq.length[i]), seq.min[i], seq.max[i], seq.replacement[i])
+		if err != nil {
+			return nil, err // nolint: wrapcheck
+		}
+
+		if seq.base[i] == decimalBase {
+			res = append(res, values)
+
+			continue
+		}
+
+		formatted := make([]string, 0, len(values))
+
+		for _, v := range values {
+			formatted = append(formatted, strconv.FormatInt(v, int(seq.base[i])))
+		}
+
+		res = append(res, formatted)
+	}
+
+	return res, nil
+}
+
+// intList reads param given either as a single value for all sequences or as array of n values.
+func intList(p params, name string, n int, def *int64, min, max int64) ([]int64, *rpcError) {
+	var list []int64
+
+	if p.has(name) && json.Unmarshal(p[name], &list) == nil {
+		if len(list) != n {
+			return nil, errMalformed(name)
+		}
+
+		for _, v := range list {
+			if v < min || v > max {
+				return nil, errOutOfRange(name, min, max)
+			}
+		}
+
+		return list, nil
+	}
+
+	v, rpcErr := p.int(name, def, min, max)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return repeat(v, n), nil
+}
+
+func boolList(p params, name string, n int, def bool) ([]bool, *rpcError) {
+	var list []bool
+
+	if p.has(name) && json.Unmarshal(p[name], &list) == nil {
+		if len(list) != n {
+			return nil, errMalformed(name)
+		}
+
+		return list, nil
+	}
+
+	v, rpcErr := p.bool(name, def)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return repeat(v, n), nil
+}
+
+func repeat[T any](v T, n int) []T {
+	res := make([]T, n)
+
+	for i := range res {
+		res[i] = v
+	}
+
+	return res
+}
+
+// bitsFor is number of bits needed for n values out of domain.
+func bitsFor(n int64, domain float64) int64 {
+	if domain <= 1 {
+		return 0
+	}
+
+	return int64(math.Ceil(float64(n) * math.Log2(domain)))
+}
+
+func errNotEnoughValues(n int64, domain float64) *rpcError {
+	return newError(codeNotEnoughValues,
+		fmt.Sprintf("You requested %d values without replacement but the domain you specified only contains %.0f",
+			n, domain),
+		n, domain)
+}
@@ -0,0 +1,99 @@
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// params gives typed access to request params reporting random.org errors.
+type params map[string]json.RawMessage
+
+func parseParams(raw json.RawMessage) (params, *rpcError) {
+	p := params{}
+
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return p, nil
+	}
+
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, newError(codeInvalidParams, "Invalid params")
+	}
+
+	return p, nil
+}
+
+func (p params) has(name string) bool {
+	v, ok := p[name]
+
+	return ok && !bytes.Equal(v, []byte("null"))
+}
+
+// decode stores param into v. Missing param keeps v when optional, else it is an error.
+func (p params) decode(name string, v interface{}, optional bool) *rpcError {
+	if !p.has(name) {
+		if optional {
+			return nil
+		}
+
+		return errMissing(name)
+	}
+
+	if err := json.Unmarshal(p[name], v); err != nil {
+		return errMalformed(name)
+	}
+
+	return nil
+}
+
+// int returns integer param in [min, max]. Def is used for missing param, nil def makes it required.
+func (p params) int(name string, def *int64, min, max int64) (int64, *rpcError) {
+	var v int64
+
+	if def != nil {
+		v = *def
+	}
+
+	if rpcErr := p.decode(name, &v, def != nil); rpcErr != nil {
+		return 0, rpcErr
+	}
+
+	if v < min || v > max {
+		return 0, errOutOfRange(name, min, max)
+	}
+
+	return v, nil
+}
+
+func (p params) float(name string, min, max float64) (float64, *rpcError) {
+	var v float64
+
+	if rpcErr := p.decode(name, &v, false); rpcErr != nil {
+		return 0, rpcErr
+	}
+
+	if v < min || v > max {
+		return 0, errOutOfRange(name, min, max)
+	}
+
+	return v, nil
+}
+
+func (p params) bool(name string, def bool) (bool, *rpcError) {
+	v := def
+
+	return v, p.decode(name, &v, true)
+}
+
+func (p params) str(name string, def *string) (string, *rpcError) {
+	var v string
+
+	if def != nil {
+		v = *def
+	}
+
+	return v, p.decode(name, &v, def != nil)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,295 @@
+// Package fakeserver implements random.org JSON-RPC 4 API locally for
+// integration tests: generate methods and their signed variants, getUsage,
+// getResult, verifySignature and ticket methods, with per key quota.
+//
+// Errors use JSON-RPC codes for protocol failures and random.org codes
+// for rejected requests:
+//
+//	200 parameter is malformed       202 parameter is out of range
+//	300 min is greater than max      301 not enough values without replacement
+//	400 unknown api key              401 api key is stopped
+//	402 daily requests exceeded      403 daily bits exceeded
+//	420 unknown ticket               421 ticket of another key
+//	422 ticket already used          423 unknown serial number
+package fakeserver
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBits     = 250_000
+	DefaultRequests = 1_000
+
+	jsonRPCVersion = "2.0"
+	signingKeyBits = 2048
+	maxBodyBytes   = 1 << 20
+)
+
+// Key is an api key known to the server.
+type Key struct {
+	APIKey       string
+	BitsLeft     int64
+	RequestsLeft int64
+	// Stopped keys are rejected with code 401.
+	Stopped bool
+}
+
+type Options struct {
+	// Keys accepted by the server. Without keys any key is accepted with default quota.
+	Keys          []Key
+	AdvisoryDelay time.Duration
+	// Rand is source of random bytes, crypto/rand if nil.
+	Rand io.Reader
+	// SigningKey signs results of generateSigned* methods. Generated if nil.
+	SigningKey *rsa.PrivateKey
+	Now        func() time.Time
+}
+
+// Server is http.Handler answering random.org JSON-RPC requests.
+type Server struct {
+	opts     Options
+	anyKey   bool
+	handlers map[string]handler
+
+	mu      sync.Mutex
+	keys    map[string]*keyState
+	results map[int64]json.RawMessage
+	owners  map[int64]string
+	tickets map[string]*ticket
+	serial  int64
+}
+
+type keyState struct {
+	Key
+	created       time.Time
+	totalBits     int64
+	totalRequests int64
+}
+
+type handler func(req *request) (interface{}, *rpcError)
+
+func New(opts Options) (*Server, error) {
+	if opts.Rand == nil {
+		opts.Rand = rand.Reader
+	}
+
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	if opts.SigningKey == nil {
+		key, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("generate signing key: %w", err)
+		}
+
+		opts.SigningKey = key
+	}
+
+	s := &Server{
+		opts:    opts,
+		anyKey:  len(opts.Keys) == 0,
+		keys:    make(map[string]*keyState),
+		results: make(map[int64]json.RawMessage),
+		owners:  make(map[int64]string),
+		tickets: make(map[string]*ticket),
+	}
+
+	for _, k := range opts.Keys {
+		s.keys[k.APIKey] = &keyState{Key: k, created: opts.Now().UTC()}
+	}
+
+	s.handlers = map[string]handler{
+		"getUsage":        s.getUsage,
+		"getResult":       s.getResult,
+		"verifySignature": s.verifySignature,
+		"createTickets":   s.createTickets,
+		"revealTickets":   s.revealTickets,
+		"listTickets":     s.listTickets,
+		"getTicket":       s.getTicket,
+	}
+
+	for name, gen := range generators {
+		s.handlers["generate"+name] = s.generate(name, gen, false)
+		s.handlers["generateSigned"+name] = s.generate(name, gen, true)
+	}
+
+	return s, nil
+}
+
+// PublicKey verifies signatures of signed results.
+func (s *Server) PublicKey() *rsa.PublicKey {
+	return &s.opts.SigningKey.PublicKey
+}
+
+// Usage returns bits and requests left of apiKey.
+func (s *Server) Usage(apiKey string) (int64, int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.keys[apiKey]
+	if !ok {
+		return 0, 0, false
+	}
+
+	return k.BitsLeft, k.RequestsLeft, true
+}
+
+type request struct {
+	method string
+	params params
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeResponse(w, rpcResponse{Error: errParse(), ID: json.RawMessage("null")})
+
+		return
+	}
+
+	writeResponse(w, s.handle(body))
+}
+
+func (s *Server) handle(body []byte) rpcResponse {
+	var req rpcRequest
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return rpcResponse{Error: errParse(), ID: json.RawMessage("null")}
+	}
+
+	if len(req.ID) == 0 {
+		req.ID = json.RawMessage("null")
+	}
+
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		return rpcResponse{Error: errInvalidRequest(), ID: req.ID}
+	}
+
+	h, ok := s.handlers[req.Method]
+	if !ok {
+		return rpcResponse{Error: errMethodNotFound(req.Method), ID: req.ID}
+	}
+
+	p, rpcErr := parseParams(req.Params)
+	if rpcErr != nil {
+		return rpcResponse{Error: rpcErr, ID: req.ID}
+	}
+
+	result, rpcErr := h(&request{method: req.Method, params: p})
+	if rpcErr != nil {
+		return rpcResponse{Error: rpcErr, ID: req.ID}
+	}
+
+	return rpcResponse{Result: result, ID: req.ID}
+}
+
+func writeResponse(w http.ResponseWriter, resp rpcResponse) {
+	resp.JSONRPC = jsonRPCVersion
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// key returns state of apiKey param, registering unknown keys when any key is accepted.
+// Caller must hold s.mu.
+func (s *Server) key(p params) (*keyState, *rpcError) {
+	apiKey, rpcErr := p.str("apiKey", nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	k, ok := s.keys[apiKey]
+
+	switch {
+	case !ok && s.anyKey:
+		k = &keyState{
+			Key:     Key{APIKey: apiKey, BitsLeft: DefaultBits, RequestsLeft: DefaultRequests},
+			created: s.opts.Now().UTC(),
+		}
+		s.keys[apiKey] = k
+	case !ok:
+		return nil, newError(codeUnknownKey, "The API key you specified does not exist")
+	}
+
+	return k, nil
+}
+
+// charge takes one request and bits from key quota.
+func (k *keyState) charge(bits int64) *rpcError {
+	if k.Stopped {
+		return newError(codeStoppedKey, "The API key you specified is stopped")
+	}
+
+	if k.RequestsLeft <= 0 {
+		return newError(codeRequestsExceeded, "The API key you specified has exceeded its daily request allowance")
+	}
+
+	if k.BitsLeft < bits {
+		return newError(codeBitsExceeded, "The API key you specified has exceeded its daily bit allowance")
+	}
+
+	k.RequestsLeft--
+	k.BitsLeft -= bits
+	k.totalRequests++
+	k.totalBits += bits
+
+	return nil
+}
+
+func (s *Server) getUsage(req *request) (interface{}, *rpcError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, rpcErr := s.key(req.params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	status := "running"
+	if k.Stopped {
+		status = "stopped"
+	}
+
+	return map[string]interface{}{
+		"status":        status,
+		"creationTime":  formatTime(k.created),
+		"bitsLeft":      k.BitsLeft,
+		"requestsLeft":  k.RequestsLeft,
+		"totalBits":     k.totalBits,
+		"totalRequests": k.totalRequests,
+	}, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05Z")
+}
@@ -0,0 +1,185 @@
+package fakeserver
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	licenseType = "developer"
+	licenseText = "Random values licensed strictly for development and testing only"
+	userDataMax = 1000
+)
+
+type signedParams struct {
+	userData json.RawMessage
+	ticketID string
+}
+
+func parseSignedParams(p params) (signedParams, *rpcError) {
+	var (
+		extra  signedParams
+		rpcErr *rpcError
+	)
+
+	if p.has("userData") {
+		if len(p["userData"]) > userDataMax {
+			return extra, errOutOfRange("userData", 0, userDataMax)
+		}
+
+		extra.userData = p["userData"]
+	}
+
+	if extra.ticketID, rpcErr = p.str("ticketId", ptr("")); rpcErr != nil {
+		return extra, rpcErr
+	}
+
+	return extra, nil
+}
+
+// sign builds signed random object, stores result for getResult and uses ticket.
+// Caller must hold s.mu.
+func (s *Server) sign(
+	method string,
+	k *keyState,
+	g *generation,
+	data interface{},
+	completion string,
+	extra signedParams,
+	t *ticket,
+) (interface{}, *rpcError) {
+	s.serial++
+
+	random := make(map[string]interface{}, len(g.fields)+10) // nolint: gomnd
+	for name, v := range g.fields {
+		random[name] = v
+	}
+
+	random["method"] = method
+	random["hashedApiKey"] = hashKey(k.APIKey)
+	random["data"] = data
+	random["completionTime"] = completion
+	random["serialNumber"] = s.serial
+	random["license"] = map[string]interface{}{"type": licenseType, "text": licenseText, "infoUrl": nil}
+	random["licenseData"] = nil
+	random["userData"] = extra.userData
+	random["ticketData"] = nil
+
+	if t != nil {
+		random["ticketData"] = map[string]interface{}{
+			"ticketId": t.id, "previousTicketId": nil, "nextTicketId": nil,
+		}
+	}
+
+	payload, err := json.Marshal(random)
+	if err != nil {
+		return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+	}
+
+	signature, err := s.signature(payload)
+	if err != nil {
+		return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+	}
+
+	result := s.result(k, g.bits, json.RawMessage(payload))
+	result["signature"] = signature
+
+	stored, err := json.Marshal(result)
+	if err != nil {
+		return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+	}
+
+	s.results[s.serial] = stored
+	s.owners[s.serial] = k.APIKey
+
+	if t != nil {
+		t.use(s.opts.Now(), s.serial, stored)
+	}
+
+	return result, nil
+}
+
+func (s *Server) signature(payload []byte) (string, error) {
+	digest := sha512.Sum512(payload)
+
+	sig, err := rsa.SignPKCS1v15(nil, s.opts.SigningKey, crypto.SHA512, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func (s *Server) getResult(req *request) (interface{}, *rpcError) {
+	serial, rpcErr := req.params.int("serialNumber", nil, 1, 1<<53) // nolint: gomnd
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, rpcErr := s.key(req.params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	stored, ok := s.results[serial]
+	if !ok || s.owners[serial] != k.APIKey {
+		return nil, newError(codeUnknownSerial, "The serial number you specified does not exist", serial)
+	}
+
+	var result map[string]json.RawMessage
+
+	if err := json.Unmarshal(stored, &result); err != nil {
+		return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+	}
+
+	// usage is reported as of now
+	delete(result, "bitsUsed")
+	result["bitsLeft"], _ = json.Marshal(k.BitsLeft)
+	result["requestsLeft"], _ = json.Marshal(k.RequestsLeft)
+
+	return result, nil
+}
+
+// verifySignature checks signature of random object exactly as it was returned by the server.
+func (s *Server) verifySignature(req *request) (interface{}, *rpcError) {
+	var signature string
+
+	if rpcErr := req.params.decode("random", &json.RawMessage{}, false); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if rpcErr := req.params.decode("signature", &signature, false); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, errMalformed("signature")
+	}
+
+	var payload bytes.Buffer
+
+	if err := json.Compact(&payload, req.params["random"]); err != nil {
+		return nil, errMalformed("random")
+	}
+
+	digest := sha512.Sum512(payload.Bytes())
+	authentic := rsa.VerifyPKCS1v15(s.PublicKey(), crypto.SHA512, digest[:], sig) == nil
+
+	return map[string]interface{}{"authenticity": authentic}, nil
+}
+
+// hashKey is base64 encoded SHA-512 of api key, as random.org includes it in signed results.
+func hashKey(apiKey string) string {
+	sum := sha512.Sum512([]byte(apiKey))
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,247 @@
+package fakeserver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	ticketsMax     = 50
+	ticketIDLength = 8
+)
+
+type ticket struct {
+	id         string
+	apiKey     string
+	showResult bool
+	created    time.Time
+	used       time.Time
+	serial     int64
+	result     json.RawMessage
+}
+
+func (t *ticket) use(now time.Time, serial int64, result json.RawMessage) {
+	t.used = now
+	t.serial = serial
+	t.result = result
+}
+
+func (t *ticket) view() map[string]interface{} {
+	v := map[string]interface{}{
+		"ticketId":         t.id,
+		"hashedApiKey":     hashKey(t.apiKey),
+		"showResult":       t.showResult,
+		"creationTime":     formatTime(t.created),
+		"usedTime":         nil,
+		"expirationTime":   nil,
+		"serialNumber":     nil,
+		"result":           nil,
+		"previousTicketId": nil,
+		"nextTicketId":     nil,
+	}
+
+	if !t.used.IsZero() {
+		v["usedTime"] = formatTime(t.used)
+		v["serialNumber"] = t.serial
+
+		if t.showResult {
+			v["result"] = t.result
+		}
+	}
+
+	return v
+}
+
+func (s *Server) createTickets(req *request) (interface{}, *rpcError) {
+	n, rpcErr := req.params.int("n", nil, 1, ticketsMax)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	showResult, rpcErr := req.params.bool("showResult", false)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, rpcErr := s.key(req.params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if rpcErr := k.charge(0); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	res := make([]interface{}, 0, n)
+
+	for i := int64(0); i < n; i++ {
+		id, err := s.ticketID()
+		if err != nil {
+			return nil, newError(codeInternal, fmt.Sprintf("Internal error: %s", err))
+		}
+
+		t := &ticket{id: id, apiKey: k.APIKey, showResult: showResult, created: s.opts.Now()}
+		s.tickets[id] = t
+
+		res = append(res, t.view())
+	}
+
+	return res, nil
+}
+
+func (s *Server) revealTickets(req *request) (interface{}, *rpcError) {
+	var id string
+
+	if rpcErr := req.params.decode("ticketId", &id, false); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, rpcErr := s.key(req.params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	t, rpcErr := s.ownTicket(id, k.APIKey)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if t.used.IsZero() {
+		return nil, newError(codeMalformed, "Ticket must be used before it can be revealed", "ticketId")
+	}
+
+	revealed := 0
+
+	if !t.showResult {
+		t.showResult = true
+		revealed = 1
+	}
+
+	return map[string]interface{}{"ticketCount": revealed}, nil
+}
+
+func (s *Server) listTickets(req *request) (interface{}, *rpcError) {
+	ticketType, rpcErr := req.params.str("ticketType", nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	switch ticketType {
+	case "singleton", "head", "tail":
+	default:
+		return nil, errMalformed("ticketType")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, rpcErr := s.key(req.params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// tickets are never chained, so every ticket is a singleton
+	if ticketType != "singleton" {
+		return []interface{}{}, nil
+	}
+
+	var owned []*ticket
+
+	for _, t := range s.tickets {
+		if t.apiKey == k.APIKey {
+			owned = append(owned, t)
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		if !owned[i].created.Equal(owned[j].created) {
+			return owned[i].created.Before(owned[j].created)
+		}
+
+		return owned[i].id < owned[j].id
+	})
+
+	res := make([]interface{}, 0, len(owned))
+
+	for _, t := range owned {
+		res = append(res, t.view())
+	}
+
+	return res, nil
+}
+
+func (s *Server) getTicket(req *request) (interface{}, *rpcError) {
+	var id string
+
+	if rpcErr := req.params.decode("ticketId", &id, false); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[id]
+	if !ok {
+		return nil, errUnknownTicket(id)
+	}
+
+	return t.view(), nil
+}
+
+// ticketToUse returns unused ticket of apiKey. Caller must hold s.mu.
+func (s *Server) ticketToUse(id, apiKey string) (*ticket, *rpcError) {
+	t, rpcErr := s.ownTicket(id, apiKey)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if !t.used.IsZero() {
+		return nil, newError(codeUsedTicket, fmt.Sprintf("Ticket '%s' has already been used", id), id)
+	}
+
+	return t, nil
+}
+
+// ownTicket returns ticket of apiKey. Caller must hold s.mu.
+func (s *Server) ownTicket(id, apiKey string) (*ticket, *rpcError) {
+	t, ok := s.tickets[id]
+	if !ok {
+		return nil, errUnknownTicket(id)
+	}
+
+	if t.apiKey != apiKey {
+		return nil, newError(codeForeignTicket,
+			fmt.Sprintf("Ticket '%s' exists but belongs to another API key", id), id)
+	}
+
+	return t, nil
+}
+
+// ticketID is random hex id. Caller must hold s.mu.
+func (s *Server) ticketID() (string, error) {
+	for {
+		b := make([]byte, ticketIDLength)
+
+		if _, err := io.ReadFull(s.opts.Rand, b); err != nil {
+			return "", fmt.Errorf("read ticket id: %w", err)
+		}
+
+		if id := hex.EncodeToString(b); s.tickets[id] == nil {
+			return id, nil
+		}
+	}
+}
+
+func errUnknownTicket(id string) *rpcError {
+	return newError(codeUnknownTicket, fmt.Sprintf("Ticket '%s' does not exist", id), id)
+}
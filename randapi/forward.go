@@ -0,0 +1,53 @@
+package randapi
+
+import (
+	"context"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/services"
+)
+
+var _ services.RequestForwarder = (*RandomOrgRetriever)(nil)
+
+// Forward sends already encoded JSON-RPC payload and returns response body as is.
+// JSON-RPC errors are part of the body and are not reported as error.
+func (svc *RandomOrgRetriever) Forward(ctx context.Context, payload []byte) ([]byte, error) {
+	method := gjson.GetBytes(payload, "method").String()
+
+	ctx, c := svc.startCall(ctx, method, gjson.GetBytes(payload, "id").String())
+
+	data, outcome, err := svc.post(ctx, payload)
+	if err != nil {
+		return nil, c.end(outcome, err)
+	}
+
+	result := gjson.GetBytes(data, "result")
+
+	if rpcErr := gjson.GetBytes(data, "error"); rpcErr.Exists() {
+		_ = c.end(OutcomeRPCError, entities.Error(rpcErr.Get("message").String()))
+
+		return data, nil
+	}
+
+	if !result.Exists() {
+		_ = c.end(OutcomeInvalidResponse, entities.Error("missing result in response"))
+
+		return data, nil
+	}
+
+	if bitsUsed := result.Get("bitsUsed"); bitsUsed.Exists() {
+		c.bitsUsed(bitsUsed.Uint())
+	}
+
+	if bitsLeft, requestsLeft := result.Get("bitsLeft"), result.Get("requestsLeft"); bitsLeft.Exists() && requestsLeft.Exists() {
+		c.remaining(bitsLeft.Uint(), requestsLeft.Uint())
+	}
+
+	_ = c.end(OutcomeSuccess, nil)
+
+	svc.delayNext(result.Get("advisoryDelay").Uint())
+
+	return data, nil
+}
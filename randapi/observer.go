@@ -0,0 +1,34 @@
+package randapi
+
+import "time"
+
+// Outcome classifies how a call to random.org ended.
+type Outcome string
+
+const (
+	OutcomeSuccess         Outcome = "success"
+	OutcomeRPCError        Outcome = "rpc_error"
+	OutcomeHTTPError       Outcome = "http_error"
+	OutcomeNetworkError    Outcome = "network_error"
+	OutcomeInvalidResponse Outcome = "invalid_response"
+	// OutcomeNotRecorded is a replayed call without recorded interaction.
+	OutcomeNotRecorded Outcome = "not_recorded"
+)
+
+// Observer receives instrumentation events of RandomOrgRetriever.
+type Observer interface {
+	// ObserveRequest is called once per call.
+	ObserveRequest(method string, outcome Outcome, duration time.Duration)
+	ObserveBitsUsed(method string, bits uint64)
+	// ObserveRemaining is called with api key allowance reported by a successful call.
+	ObserveRemaining(bitsLeft, requestsLeft uint64)
+	// ObserveAdvisoryWait is called when a call waited for advisory delay of the previous one.
+	ObserveAdvisoryWait(d time.Duration)
+}
+
+type nopObserver struct{}
+
+func (nopObserver) ObserveRequest(string, Outcome, time.Duration) {}
+func (nopObserver) ObserveBitsUsed(string, uint64)                {}
+func (nopObserver) ObserveRemaining(uint64, uint64)               {}
+func (nopObserver) ObserveAdvisoryWait(time.Duration)             {}
@@ -5,8 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/bohdanch-w/rand-api/entities"
 	"github.com/google/uuid"
@@ -30,43 +28,31 @@ func (svc *RandomOrgRetriever) GetUsage(ctx context.Context, apiKey string) (ent
 		return usage, fmt.Errorf("encode payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, svc.apiPath, buf)
-	if err != nil {
-		return usage, fmt.Errorf("create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "rand-api/0.1")
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return usage, fmt.Errorf("execute request: %w", err)
-	}
-
-	defer resp.Body.Close()
+	ctx, c := svc.startCall(ctx, randReq.Method, randReq.ID.String())
 
-	data, err := io.ReadAll(resp.Body)
+	data, outcome, err := svc.post(ctx, buf.Bytes())
 	if err != nil {
-		return usage, fmt.Errorf("read body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return usage, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+		return usage, c.end(outcome, err)
 	}
 
 	var randResp UsageStatusResponse
 
 	if err := randResp.parse(data); err != nil {
-		return usage, fmt.Errorf("invalid response: %w", err)
+		return usage, c.end(parseOutcome(err), fmt.Errorf("invalid response: %w", err))
 	}
 
 	if randResp.ID != randReq.ID {
-		return usage, fmt.Errorf("%w: %s != %s", ErrRequestResponseMissmatch, randResp.ID.String(), randReq.ID.String())
+		err := fmt.Errorf("%w: %s != %s", ErrRequestResponseMissmatch, randResp.ID.String(), randReq.ID.String())
+
+		return usage, c.end(OutcomeInvalidResponse, err)
 	}
 
 	usage = *randResp.Result
 	usage.APIKey = apiKey
 
+	c.remaining(usage.BitsLeft, usage.RequestsLeft)
+	_ = c.end(OutcomeSuccess, nil)
+
 	return usage, nil
 }
 
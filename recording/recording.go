@@ -0,0 +1,295 @@
+// Package recording saves JSON-RPC exchanges with random.org and serves them
+// back later, so a session can be rerun offline.
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/bohdanch-w/rand-api/randapi"
+)
+
+const (
+	fileExt  = ".json"
+	filePerm = 0o600
+	dirPerm  = 0o700
+	apiKey   = "apiKey"
+)
+
+// Interaction is a single request and response pair. Api key is never stored.
+type Interaction struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Status int             `json:"status"`
+	// Response is JSON body as is, or JSON string when body is not valid JSON.
+	Response json.RawMessage `json:"response"`
+}
+
+// NewRecorder returns transport sending requests with next and saving every
+// exchange to dir. Numbering continues after interactions already in dir.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("create recording dir: %w", err)
+	}
+
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{dir: dir, next: next, seq: len(files)}, nil
+}
+
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+func (rec *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rec.next.RoundTrip(req)
+	if err != nil {
+		return nil, err // nolint: wrapcheck
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := rec.save(payload, resp.StatusCode, body); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (rec *Recorder) save(payload []byte, status int, body []byte) error {
+	params, err := redact(gjson.GetBytes(payload, "params").Raw)
+	if err != nil {
+		return err
+	}
+
+	response := body
+	if !json.Valid(body) {
+		response, _ = json.Marshal(string(body)) // nolint: errchkjson
+	}
+
+	method := gjson.GetBytes(payload, "method").String()
+
+	data, err := json.MarshalIndent(Interaction{
+		Method:   method,
+		Params:   params,
+		Status:   status,
+		Response: response,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode interaction: %w", err)
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.seq++
+	name := fmt.Sprintf("%04d-%s%s", rec.seq, method, fileExt)
+
+	if err := os.WriteFile(filepath.Join(rec.dir, name), append(data, '\n'), filePerm); err != nil {
+		return fmt.Errorf("write interaction: %w", err)
+	}
+
+	return nil
+}
+
+// NewReplayer returns transport answering requests with interactions saved
+// in dir. Requests match on method and params, ignoring id and api key.
+// Identical requests get recorded responses in recorded order.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &Replayer{interactions: make(map[string][]Interaction)}
+
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read interaction: %w", err)
+		}
+
+		var in Interaction
+
+		if err := json.Unmarshal(data, &in); err != nil {
+			return nil, fmt.Errorf("decode interaction %s: %w", name, err)
+		}
+
+		key, err := matchKey(in.Method, string(in.Params))
+		if err != nil {
+			return nil, fmt.Errorf("interaction %s: %w", name, err)
+		}
+
+		rep.interactions[key] = append(rep.interactions[key], in)
+	}
+
+	return rep, nil
+}
+
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+}
+
+func (rep *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	payload, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	method := gjson.GetBytes(payload, "method").String()
+
+	key, err := matchKey(method, gjson.GetBytes(payload, "params").Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	in, ok := rep.next(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", randapi.ErrNotRecorded, method)
+	}
+
+	body := []byte(in.Response)
+
+	var text string
+	if json.Unmarshal(in.Response, &text) == nil {
+		body = []byte(text)
+	} else if id := gjson.GetBytes(payload, "id"); id.Exists() && gjson.GetBytes(body, "id").Exists() {
+		// response must answer this request, not the recorded one
+		if body, err = sjson.SetRawBytes(body, "id", []byte(id.Raw)); err != nil {
+			return nil, fmt.Errorf("set response id: %w", err)
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// next pops the oldest interaction for key. The last one is reused once the rest are spent.
+func (rep *Replayer) next(key string) (Interaction, bool) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+
+	queue := rep.interactions[key]
+	if len(queue) == 0 {
+		return Interaction{}, false
+	}
+
+	if len(queue) > 1 {
+		rep.interactions[key] = queue[1:]
+	}
+
+	return queue[0], true
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request: %w", err)
+	}
+
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+
+	return payload, nil
+}
+
+func redact(params string) (json.RawMessage, error) {
+	if params == "" {
+		return json.RawMessage("null"), nil
+	}
+
+	redacted, err := sjson.Delete(params, apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("redact params: %w", err)
+	}
+
+	return json.RawMessage(redacted), nil
+}
+
+// matchKey is method with params re-encoded in canonical form without api key.
+func matchKey(method, params string) (string, error) {
+	redacted, err := redact(params)
+	if err != nil {
+		return "", err
+	}
+
+	var v interface{}
+
+	if err := json.Unmarshal(redacted, &v); err != nil {
+		return "", fmt.Errorf("decode params: %w", err)
+	}
+
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("encode params: %w", err)
+	}
+
+	return method + " " + string(canonical), nil
+}
+
+func interactionFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read recording dir: %w", err)
+	}
+
+	var files []string
+
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), fileExt) {
+			files = append(files, e.Name())
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
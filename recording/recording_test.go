@@ -0,0 +1,133 @@
+package recording_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/bohdanch-w/rand-api/backend"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/recording"
+)
+
+const (
+	apiKey   = "6b81b415-80e9-4481-a5f1-58e354742c00" // nolint: gosec
+	response = `{"jsonrpc":"2.0","result":{"random":{"data":[4, 2],"completionTime":"2022-08-25 12:15:44Z"},` +
+		`"bitsUsed":6,"bitsLeft":249994,"requestsLeft":999,"advisoryDelay":0},"id":""}`
+)
+
+func generate(t *testing.T, client *http.Client, url string, params map[string]interface{}) ([]byte, error) {
+	t.Helper()
+
+	svc := randapi.NewRandomOrgRetriever(url, client, false)
+
+	req, err := svc.NewRequest("generateIntegers", params)
+	require.NoError(t, err)
+
+	result, err := svc.ExecuteRequest(context.Background(), &req)
+
+	return result.Random.Data, err
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		resp, err := sjson.Set(response, "id", gjson.GetBytes(body, "id").String())
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(resp))
+		require.NoError(t, err)
+	}))
+
+	rec, err := recording.NewRecorder(dir, nil)
+	require.NoError(t, err)
+
+	params := map[string]interface{}{"apiKey": apiKey, "n": 2, "min": 1, "max": 6}
+
+	data, err := generate(t, &http.Client{Transport: rec}, ts.URL, params)
+	require.NoError(t, err)
+	require.JSONEq(t, `[4, 2]`, string(data))
+
+	ts.Close()
+
+	saved, err := os.ReadFile(filepath.Join(dir, "0001-generateIntegers.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(saved), apiKey)
+
+	rep, err := recording.NewReplayer(dir)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: rep}
+
+	// another key and request id still match
+	params["apiKey"] = "c6418ada-7874-4907-9367-f43c446686d3"
+
+	data, err = generate(t, client, ts.URL, params)
+	require.NoError(t, err)
+	require.JSONEq(t, `[4, 2]`, string(data))
+
+	params["max"] = 7
+
+	_, err = generate(t, client, ts.URL, params)
+	require.ErrorIs(t, err, randapi.ErrNotRecorded)
+}
+
+func TestRecord_ContinuesNumbering(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0001-getUsage.json"), []byte(`{}`), 0o600))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("maintenance"))
+	}))
+	defer ts.Close()
+
+	rec, err := recording.NewRecorder(dir, nil)
+	require.NoError(t, err)
+
+	_, err = generate(t, &http.Client{Transport: rec}, ts.URL, map[string]interface{}{"n": 1})
+	require.ErrorIs(t, err, randapi.ErrUnexpectedStatusCode)
+
+	saved, err := os.ReadFile(filepath.Join(dir, "0002-generateIntegers.json"))
+	require.NoError(t, err)
+	require.Equal(t, int64(503), gjson.GetBytes(saved, "status").Int())
+	require.Equal(t, "maintenance", gjson.GetBytes(saved, "response").String())
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "0001-getUsage.json")))
+
+	rep, err := recording.NewReplayer(dir)
+	require.NoError(t, err)
+
+	_, err = generate(t, &http.Client{Transport: rep}, ts.URL, map[string]interface{}{"n": 1})
+	require.ErrorIs(t, err, randapi.ErrUnexpectedStatusCode)
+}
+
+func TestReplay_MissSkipsFallback(t *testing.T) {
+	rep, err := recording.NewReplayer(t.TempDir())
+	require.NoError(t, err)
+
+	fallback := backend.NewFallback(randapi.NewRandomOrgRetriever("http://127.0.0.1", &http.Client{Transport: rep}, false), nil)
+
+	req, err := fallback.NewRequest("generateIntegers", map[string]interface{}{"n": 1, "min": 1, "max": 6})
+	require.NoError(t, err)
+
+	// replay miss is not a network failure, fallback must not hide it
+	_, err = fallback.ExecuteRequest(context.Background(), &req)
+	require.ErrorIs(t, err, randapi.ErrNotRecorded)
+	require.NotErrorIs(t, err, randapi.ErrUnreachable)
+	require.EqualError(t, err, "no recorded interaction: generateIntegers")
+	require.False(t, fallback.Used())
+}
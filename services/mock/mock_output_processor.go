@@ -11,7 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockOutputProcessor is a mock of OutputProcessor interface.
+// MockOutputProcessor is a mock of OutputGenerator interface.
 type MockOutputProcessor struct {
 	ctrl     *gomock.Controller
 	recorder *MockOutputProcessorMockRecorder
@@ -34,6 +34,20 @@ func (m *MockOutputProcessor) EXPECT() *MockOutputProcessorMockRecorder {
 	return m.recorder
 }
 
+// GenerateHistoryOutput mocks base method.
+func (m *MockOutputProcessor) GenerateHistoryOutput(history entities.UsageHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateHistoryOutput", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateHistoryOutput indicates an expected call of GenerateHistoryOutput.
+func (mr *MockOutputProcessorMockRecorder) GenerateHistoryOutput(history interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateHistoryOutput", reflect.TypeOf((*MockOutputProcessor)(nil).GenerateHistoryOutput), history)
+}
+
 // GenerateRandOutput mocks base method.
 func (m *MockOutputProcessor) GenerateRandOutput(data []interface{}, apiInfo entities.APIInfo) error {
 	m.ctrl.T.Helper()
@@ -5,4 +5,5 @@ import "github.com/bohdanch-w/rand-api/entities"
 type OutputGenerator interface {
 	GenerateRandOutput(data []interface{}, apiInfo entities.APIInfo) error
 	GenerateUsageOutput(status entities.UsageStatus) error
+	GenerateHistoryOutput(history entities.UsageHistory) error
 }
@@ -13,3 +13,8 @@ type RandRetiever interface {
 	ExecuteRequest(ctx context.Context, randReq *entities.RandomRequest) (entities.RandResponseResult, error)
 	GetUsage(ctx context.Context, apiKey string) (entities.UsageStatus, error)
 }
+
+// RequestForwarder sends raw JSON-RPC payloads to the randomness server.
+type RequestForwarder interface {
+	Forward(ctx context.Context, payload []byte) ([]byte, error)
+}
@@ -0,0 +1,26 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const DefaultOTLPEndpoint = "http://localhost:4318/v1/traces"
+
+// NewOTLPExporter creates exporter posting spans to collector traces url
+// over OTLP/HTTP. Empty endpoint means DefaultOTLPEndpoint.
+func NewOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	if endpoint == "" {
+		endpoint = DefaultOTLPEndpoint
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	return exporter, nil
+}
@@ -0,0 +1,201 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/internal/build"
+)
+
+const (
+	ErrUnknownExporter = entities.Error("unknown trace exporter")
+
+	ServiceName = "randapi"
+
+	instrumentation = "github.com/bohdanch-w/rand-api/cmd"
+)
+
+type Exporter string
+
+const (
+	ExporterNone   Exporter = ""
+	ExporterOTLP   Exporter = "otlp"
+	ExporterStdout Exporter = "stdout"
+	ExporterFile   Exporter = "file"
+)
+
+type Config struct {
+	Exporter Exporter
+	// Endpoint is OTLP/HTTP traces url of the collector.
+	Endpoint string
+	// File spans are written to by file exporter.
+	File string
+}
+
+// ShutdownFunc flushes pending spans and releases exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs global tracer provider and W3C trace context propagator.
+// With ExporterNone tracing stays disabled and shutdown is a no-op.
+func Setup(cfg Config) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = NewOTLPExporter(context.Background(), cfg.Endpoint)
+	case ExporterStdout:
+		// stdout is reserved for generated values
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case ExporterFile:
+		var f *os.File
+
+		f, err = os.OpenFile(cfg.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // nolint: gomnd
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(build.Version),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+
+		if closer != nil {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("shutdown tracing: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+// FromEnvironment returns ctx carrying trace context passed by calling job
+// in TRACEPARENT and TRACESTATE environment variables.
+func FromEnvironment(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+
+	if v := os.Getenv("TRACEPARENT"); v != "" {
+		carrier["traceparent"] = v
+	}
+
+	if v := os.Getenv("TRACESTATE"); v != "" {
+		carrier["tracestate"] = v
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// WrapCommands makes every command action run in its own span.
+func WrapCommands(commands []*cli.Command) {
+	wrapCommands(commands, ServiceName)
+}
+
+func wrapCommands(commands []*cli.Command, prefix string) {
+	for _, cmd := range commands {
+		name := prefix + " " + cmd.Name
+
+		if cmd.Action != nil {
+			cmd.Action = wrapAction(name, cmd.Action)
+		}
+
+		wrapCommands(cmd.Subcommands, name)
+	}
+}
+
+func wrapAction(name string, action cli.ActionFunc) cli.ActionFunc {
+	return func(cCtx *cli.Context) error {
+		ctx, span := otel.Tracer(instrumentation).Start(
+			FromEnvironment(cCtx.Context),
+			name,
+			trace.WithAttributes(attribute.StringSlice("randapi.args", cCtx.Args().Slice())),
+		)
+		defer span.End()
+
+		cCtx.Context = ctx
+
+		err := action(cCtx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	}
+}
+
+// ParseExporter validates exporter name given on command line.
+func ParseExporter(s string) (Exporter, error) {
+	switch e := Exporter(strings.ToLower(s)); e {
+	case ExporterNone, ExporterOTLP, ExporterStdout, ExporterFile:
+		return e, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownExporter, s)
+	}
+}
+
+// Handler runs every request of next in a server span continuing
+// trace context sent by the client.
+func Handler(next http.Handler, name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := otel.Tracer(instrumentation).Start(ctx, name+" "+r.Method+" "+r.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
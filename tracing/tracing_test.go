@@ -0,0 +1,170 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bohdanch-w/rand-api/entities"
+	"github.com/bohdanch-w/rand-api/randapi"
+	"github.com/bohdanch-w/rand-api/tracing"
+)
+
+const response = `{"jsonrpc":"2.0","result":{"random":{"data":[4],"completionTime":"2022-08-25 12:15:44Z"},` +
+	`"bitsUsed":3,"bitsLeft":249997,"requestsLeft":999,"advisoryDelay":0},"id":""}`
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	return recorder
+}
+
+func newRetriever(t *testing.T) *randapi.RandomOrgRetriever {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		resp, err := sjson.Set(response, "id", gjson.GetBytes(body, "id").String())
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(resp))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+
+	return randapi.NewRandomOrgRetriever(ts.URL, http.DefaultClient, false)
+}
+
+func TestWrapCommands(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	t.Setenv("TRACEPARENT", traceParent)
+
+	recorder := setupRecorder(t)
+	svc := newRetriever(t)
+
+	app := &cli.App{
+		Name: "randapi",
+		Commands: []*cli.Command{
+			{
+				Name: "integer",
+				Action: func(cCtx *cli.Context) error {
+					req, err := svc.NewRequest("generateIntegers", map[string]int{"n": 1})
+					require.NoError(t, err)
+
+					_, err = svc.ExecuteRequest(cCtx.Context, &req)
+
+					return err
+				},
+			},
+			{
+				Name:   "fail",
+				Action: func(*cli.Context) error { return entities.Error("test error") },
+			},
+		},
+	}
+
+	tracing.WrapCommands(app.Commands)
+
+	require.NoError(t, app.Run([]string{"randapi", "integer"}))
+	require.EqualError(t, app.Run([]string{"randapi", "fail"}), "test error")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	call, cmd, failed := spans[0], spans[1], spans[2]
+
+	require.Equal(t, "random.org generateIntegers", call.Name())
+	require.Equal(t, cmd.SpanContext().SpanID(), call.Parent().SpanID())
+	require.Contains(t, call.Attributes(), attribute.String("randapi.outcome", string(randapi.OutcomeSuccess)))
+
+	require.Equal(t, "randapi integer", cmd.Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", cmd.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", cmd.Parent().SpanID().String())
+
+	require.Equal(t, "randapi fail", failed.Name())
+	require.Equal(t, codes.Error, failed.Status().Code)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var received []*collectortrace.ExportTraceServiceRequest
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var req collectortrace.ExportTraceServiceRequest
+
+		require.NoError(t, proto.Unmarshal(body, &req))
+
+		received = append(received, &req)
+	}))
+	defer collector.Close()
+
+	exporter, err := tracing.NewOTLPExporter(context.Background(), collector.URL+"/v1/traces")
+	require.NoError(t, err)
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, child := provider.Tracer("test").Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+	// syncer exports spans one by one as they end
+	require.Len(t, received, 2)
+	require.Equal(t, "test", received[0].GetResourceSpans()[0].GetScopeSpans()[0].GetScope().GetName())
+
+	childSpan := received[0].GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()[0]
+	require.Equal(t, "child", childSpan.GetName())
+	require.Equal(t, parent.SpanContext().SpanID().String(), hex.EncodeToString(childSpan.GetParentSpanId()))
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, childSpan.GetStatus().GetCode())
+	require.Equal(t, "boom", childSpan.GetStatus().GetMessage())
+
+	parentSpan := received[1].GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()[0]
+	require.Equal(t, "parent", parentSpan.GetName())
+	require.Equal(t, parent.SpanContext().TraceID().String(), hex.EncodeToString(parentSpan.GetTraceId()))
+	require.Empty(t, parentSpan.GetParentSpanId())
+}
+
+func TestParseExporter(t *testing.T) {
+	exporter, err := tracing.ParseExporter("OTLP")
+	require.NoError(t, err)
+	require.Equal(t, tracing.ExporterOTLP, exporter)
+
+	_, err = tracing.ParseExporter("jaeger")
+	require.ErrorIs(t, err, tracing.ErrUnknownExporter)
+}